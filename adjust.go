@@ -0,0 +1,42 @@
+package stopwatch
+
+import "time"
+
+// SetElapsed sets the elapsed time of the session to d, e.g. to credit time
+// accumulated by another timing system. A running stopwatch keeps running
+// from d. Negative durations are treated as zero. It has no effect on a
+// reseted stopwatch, which has to be started first.
+func (s *Stopwatch) SetElapsed(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.setElapsed(d)
+}
+
+// AddElapsed adds d to the elapsed time of the session, a negative d
+// subtracts it, e.g. to exclude a known overhead. The elapsed time doesn't
+// drop below zero. It has no effect on a reseted stopwatch.
+func (s *Stopwatch) AddElapsed(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.setElapsed(s.elapsed() + d)
+}
+
+// setElapsed moves the start of the session so that the elapsed time is d.
+// The lock must be held.
+func (s *Stopwatch) setElapsed(d time.Duration) {
+	if s.isReseted() {
+		return
+	}
+	if d < 0 {
+		d = 0
+	}
+
+	if s.isStopped() {
+		s.start = s.stop.Add(-d)
+		s.publish()
+		return
+	}
+	s.start = s.now().Add(-d)
+	s.publish()
+	s.armWatchdog()
+}
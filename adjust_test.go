@@ -0,0 +1,90 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStopwatch_SetElapsed(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+
+	c.Advance(time.Second)
+	sw.SetElapsed(time.Hour)
+	c.Advance(time.Second)
+	if e := sw.ElapsedTime(); e != time.Hour+time.Second {
+		t.Errorf("SetElapsed: got: %s expected: 1h0m1s\n", e)
+	}
+
+	sw.Stop()
+	sw.SetElapsed(time.Minute)
+	if e := sw.ElapsedTime(); e != time.Minute || !sw.IsStopped() {
+		t.Errorf("SetElapsed: got: %s expected a stopped stopwatch at 1m0s\n", e)
+	}
+
+	sw.SetElapsed(0)
+	if e := sw.ElapsedTime(); e != 0 || !sw.IsStopped() {
+		t.Errorf("SetElapsed: got: %s expected a stopped stopwatch at 0s\n", e)
+	}
+
+	sw.SetElapsed(-time.Minute)
+	if e := sw.ElapsedTime(); e != 0 || !sw.IsStopped() {
+		t.Errorf("SetElapsed: got: %s expected a stopped stopwatch at 0s\n", e)
+	}
+
+	n := New(WithClock(c))
+	n.SetElapsed(time.Minute)
+	if !n.IsReseted() {
+		t.Error("SetElapsed: a reseted stopwatch should not be changed")
+	}
+}
+
+func TestStopwatch_AddElapsed(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+
+	c.Advance(10 * time.Second)
+	sw.AddElapsed(time.Minute)
+	if e := sw.ElapsedTime(); e != 70*time.Second {
+		t.Errorf("AddElapsed: got: %s expected: 1m10s\n", e)
+	}
+
+	sw.AddElapsed(-5 * time.Second)
+	if e := sw.ElapsedTime(); e != 65*time.Second {
+		t.Errorf("AddElapsed: got: %s expected: 1m5s\n", e)
+	}
+
+	sw.AddElapsed(-time.Hour)
+	if e := sw.ElapsedTime(); e != 0 {
+		t.Errorf("AddElapsed: got: %s expected: 0\n", e)
+	}
+}
+
+func TestResume(t *testing.T) {
+	c := newClock()
+
+	sw := Resume(time.Hour, true, WithClock(c))
+	c.Advance(time.Second)
+	if e := sw.ElapsedTime(); e != time.Hour+time.Second || sw.IsStopped() {
+		t.Errorf("Resume: got: %s expected a running stopwatch at 1h0m1s\n", e)
+	}
+
+	sw = Resume(time.Hour, false, WithClock(c))
+	c.Advance(time.Second)
+	if e := sw.ElapsedTime(); e != time.Hour || !sw.IsStopped() {
+		t.Errorf("Resume: got: %s expected a stopped stopwatch at 1h0m0s\n", e)
+	}
+
+	sw.Start(0)
+	c.Advance(time.Second)
+	if e := sw.ElapsedTime(); e != time.Hour+time.Second {
+		t.Errorf("Start: got: %s expected: 1h0m1s\n", e)
+	}
+
+	if sw := Resume(0, false, WithClock(c)); !sw.IsReseted() {
+		t.Error("Resume: expected a reseted stopwatch without elapsed time")
+	}
+}
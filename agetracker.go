@@ -0,0 +1,129 @@
+package stopwatch
+
+import (
+	"sync"
+	"time"
+)
+
+// AgeTracker tracks the ages of many items, such as cache entries, with a
+// single clock. Use it instead of a Stopwatch per item. It is safe for
+// concurrent use.
+type AgeTracker struct {
+	mu    sync.Mutex
+	clock Clock
+	items map[string]ageEntry
+}
+
+type ageEntry struct {
+	inserted, touched time.Time
+}
+
+// NewAgeTracker creates a new AgeTracker using the given clock. A nil clock
+// uses the system clock.
+func NewAgeTracker(clock Clock) *AgeTracker {
+	return &AgeTracker{
+		clock: clockOrSystem(clock),
+		items: make(map[string]ageEntry),
+	}
+}
+
+// Insert starts tracking the given keys. Keys that are already tracked start
+// over with a zero age.
+func (a *AgeTracker) Insert(keys ...string) {
+	a.mu.Lock()
+	now := a.clock.Now()
+	for _, key := range keys {
+		a.items[key] = ageEntry{inserted: now, touched: now}
+	}
+	a.mu.Unlock()
+}
+
+// Touch marks the given keys as used, which resets their idle time. Keys
+// that are not tracked are ignored.
+func (a *AgeTracker) Touch(keys ...string) {
+	a.mu.Lock()
+	now := a.clock.Now()
+	for _, key := range keys {
+		if e, ok := a.items[key]; ok {
+			e.touched = now
+			a.items[key] = e
+		}
+	}
+	a.mu.Unlock()
+}
+
+// Remove stops tracking the given keys.
+func (a *AgeTracker) Remove(keys ...string) {
+	a.mu.Lock()
+	for _, key := range keys {
+		delete(a.items, key)
+	}
+	a.mu.Unlock()
+}
+
+// Len returns the number of tracked keys.
+func (a *AgeTracker) Len() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.items)
+}
+
+// Age returns the duration since key was inserted. The boolean is false if
+// the key is not tracked.
+func (a *AgeTracker) Age(key string) (time.Duration, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	e, ok := a.items[key]
+	if !ok {
+		return 0, false
+	}
+	return a.clock.Now().Sub(e.inserted), true
+}
+
+// Idle returns the duration since key was inserted or last touched. The
+// boolean is false if the key is not tracked.
+func (a *AgeTracker) Idle(key string) (time.Duration, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	e, ok := a.items[key]
+	if !ok {
+		return 0, false
+	}
+	return a.clock.Now().Sub(e.touched), true
+}
+
+// OlderThan returns all keys with an age greater than d.
+func (a *AgeTracker) OlderThan(d time.Duration) []string {
+	return a.filter(func(now time.Time, e ageEntry) bool { return now.Sub(e.inserted) > d }, false)
+}
+
+// IdleFor returns all keys that were not touched for longer than d.
+func (a *AgeTracker) IdleFor(d time.Duration) []string {
+	return a.filter(func(now time.Time, e ageEntry) bool { return now.Sub(e.touched) > d }, false)
+}
+
+// Prune removes all keys with an age greater than maxAge and returns them.
+func (a *AgeTracker) Prune(maxAge time.Duration) []string {
+	return a.filter(func(now time.Time, e ageEntry) bool { return now.Sub(e.inserted) > maxAge }, true)
+}
+
+// filter returns the keys matching fn and removes them if remove is true.
+func (a *AgeTracker) filter(fn func(now time.Time, e ageEntry) bool, remove bool) []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var keys []string
+	now := a.clock.Now()
+	for key, e := range a.items {
+		if fn(now, e) {
+			keys = append(keys, key)
+			if remove {
+				delete(a.items, key)
+			}
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,50 @@
+package stopwatch
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestAgeTracker(t *testing.T) {
+	c := newClock()
+	a := NewAgeTracker(c)
+
+	a.Insert("a", "b")
+	c.Advance(time.Minute)
+	a.Insert("c")
+	a.Touch("a", "unknown")
+	c.Advance(time.Minute)
+
+	if age, ok := a.Age("a"); !ok || age != 2*time.Minute {
+		t.Errorf("Age: got: %s expected: %s\n", age, 2*time.Minute)
+	}
+
+	if idle, ok := a.Idle("a"); !ok || idle != time.Minute {
+		t.Errorf("Idle: got: %s expected: %s\n", idle, time.Minute)
+	}
+
+	if _, ok := a.Age("unknown"); ok {
+		t.Error("Age: untracked keys should not be reported")
+	}
+
+	older := a.OlderThan(90 * time.Second)
+	sort.Strings(older)
+	if len(older) != 2 || older[0] != "a" || older[1] != "b" {
+		t.Errorf("OlderThan: got: %v expected: [a b]\n", older)
+	}
+
+	if idle := a.IdleFor(90 * time.Second); len(idle) != 1 || idle[0] != "b" {
+		t.Errorf("IdleFor: got: %v expected: [b]\n", idle)
+	}
+
+	pruned := a.Prune(90 * time.Second)
+	if len(pruned) != 2 || a.Len() != 1 {
+		t.Errorf("Prune: got: %v, %d keys left\n", pruned, a.Len())
+	}
+
+	a.Remove("c")
+	if a.Len() != 0 {
+		t.Errorf("Remove: got: %d keys expected: 0\n", a.Len())
+	}
+}
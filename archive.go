@@ -0,0 +1,243 @@
+package stopwatch
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrNothingToArchive is returned by Archive.Add for a reseted stopwatch.
+var ErrNothingToArchive = errors.New("stopwatch: nothing to archive")
+
+// ArchivedSession is a single session stored in an Archive.
+type ArchivedSession struct {
+	Start   time.Time       `json:"start"`
+	End     time.Time       `json:"end"`
+	Elapsed time.Duration   `json:"elapsed_ns"`
+	Laps    []time.Duration `json:"laps_ns,omitempty"`
+}
+
+// Retention limits what an Archive keeps. Zero values mean no limit. When a
+// limit is exceeded the oldest sessions are pruned first.
+type Retention struct {
+	MaxSessions int           // number of sessions
+	MaxAge      time.Duration // age of a session, measured from its end
+	MaxSize     int64         // uncompressed size of the archive in bytes
+}
+
+// Archive persists finished sessions in a file, one JSON object per line.
+// The retention policy is applied every time a session is added. The file is
+// compressed if its extension belongs to a registered Compressor, such as
+// "sessions.jsonl.gz". It is safe for concurrent use.
+type Archive struct {
+	mu        sync.Mutex
+	path      string
+	retention Retention
+	clock     Clock
+}
+
+// NewArchive creates an Archive backed by the file at path using the given
+// retention policy. The file is created once the first session is added. A
+// nil clock uses the system clock.
+func NewArchive(path string, r Retention, clock Clock) *Archive {
+	return &Archive{
+		path:      path,
+		retention: r,
+		clock:     clockOrSystem(clock),
+	}
+}
+
+// Add appends the current session of s to the archive and prunes the
+// archive afterwards. A running session is archived with its elapsed time so
+// far.
+func (a *Archive) Add(s *Stopwatch) error {
+	offset, err := s.anchorOffset()
+	if err != nil {
+		return err
+	}
+
+	session, ok := s.archive(offset)
+	if !ok {
+		return ErrNothingToArchive
+	}
+
+	line, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	f, err := os.OpenFile(a.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	c, _ := compressorFor(a.path)
+	err = compressTo(f, c, func(w io.Writer) error {
+		_, err := w.Write(append(line, '\n'))
+		return err
+	})
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return a.prune()
+}
+
+// Sessions returns all archived sessions, the oldest first.
+func (a *Archive) Sessions() ([]ArchivedSession, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	sessions, _, err := a.read()
+	return sessions, err
+}
+
+// Prune applies the retention policy to the archive. It is called by Add, so
+// it is only needed to enforce MaxAge on an archive that is not written to.
+func (a *Archive) Prune() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.prune()
+}
+
+// prune drops the oldest sessions exceeding the retention policy and
+// rewrites the file if anything was dropped. The lock must be held.
+func (a *Archive) prune() error {
+	r := a.retention
+	if r.MaxSessions <= 0 && r.MaxAge <= 0 && r.MaxSize <= 0 {
+		return nil
+	}
+
+	sessions, lines, err := a.read()
+	if err != nil {
+		return err
+	}
+
+	var size int64
+	for _, line := range lines {
+		size += int64(len(line)) + 1
+	}
+
+	now := a.clock.Now()
+	drop := 0
+	for drop < len(sessions) {
+		expired := r.MaxAge > 0 && now.Sub(sessions[drop].End) > r.MaxAge
+		tooMany := r.MaxSessions > 0 && len(sessions)-drop > r.MaxSessions
+		tooLarge := r.MaxSize > 0 && size > r.MaxSize
+		if !expired && !tooMany && !tooLarge {
+			break
+		}
+
+		size -= int64(len(lines[drop])) + 1
+		drop++
+	}
+
+	if drop == 0 {
+		return nil
+	}
+
+	c, _ := compressorFor(a.path)
+	return writeFileAtomic(a.path, func(w io.Writer) error {
+		return compressTo(w, c, func(w io.Writer) error {
+			for _, line := range lines[drop:] {
+				if _, err := w.Write(append(line, '\n')); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// read returns the archived sessions together with their compact encoded
+// lines. A missing file is an empty archive. The lock must be held.
+func (a *Archive) read() ([]ArchivedSession, []json.RawMessage, error) {
+	f, err := os.Open(a.path)
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if c, _ := compressorFor(a.path); c != nil {
+		cr, err := c.NewReader(f)
+		if err == io.EOF { // empty file
+			return nil, nil, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		defer cr.Close()
+		r = cr
+	}
+
+	var sessions []ArchivedSession
+	var lines []json.RawMessage
+	dec := json.NewDecoder(r)
+	for {
+		var line json.RawMessage
+		if err := dec.Decode(&line); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		var session ArchivedSession
+		if err := json.Unmarshal(line, &session); err != nil {
+			return nil, nil, err
+		}
+
+		sessions = append(sessions, session)
+		lines = append(lines, line)
+	}
+
+	return sessions, lines, nil
+}
+
+// archive returns the current session of s with the wall clock timestamps
+// shifted by offset. It returns false for a reseted stopwatch.
+func (s *Stopwatch) archive(offset time.Duration) (ArchivedSession, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.isReseted() {
+		return ArchivedSession{}, false
+	}
+
+	session := ArchivedSession{
+		Start:   s.start,
+		End:     s.now(),
+		Elapsed: s.elapsed(),
+		Laps:    make([]time.Duration, 0, len(s.laps)),
+	}
+
+	if len(s.runs) > 0 {
+		session.Start = s.runs[0].from
+	}
+
+	if s.isStopped() {
+		session.End = s.stop
+	}
+
+	for _, lap := range s.lapRecords() {
+		session.Laps = append(session.Laps, lap.Duration)
+	}
+
+	session.Start = session.Start.Add(offset)
+	session.End = session.End.Add(offset)
+	return session, true
+}
@@ -0,0 +1,115 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestArchive_Add(t *testing.T) {
+	c := newClock()
+	path := filepath.Join(t.TempDir(), "sessions.jsonl")
+	a := NewArchive(path, Retention{}, c)
+
+	if err := a.Add(New(WithClock(c))); !errors.Is(err, ErrNothingToArchive) {
+		t.Errorf("Add: got: %v expected: %v\n", err, ErrNothingToArchive)
+	}
+
+	sw := Start(0, WithClock(c))
+	c.Advance(time.Second)
+	sw.Lap()
+	c.Advance(2 * time.Second)
+	sw.Stop()
+	c.Advance(time.Minute)
+
+	if err := a.Add(sw); err != nil {
+		t.Fatalf("Add: error: %s\n", err)
+	}
+
+	sessions, err := a.Sessions()
+	if err != nil {
+		t.Fatalf("Sessions: error: %s\n", err)
+	}
+
+	if len(sessions) != 1 {
+		t.Fatalf("Sessions: got: %d sessions expected: %d\n", len(sessions), 1)
+	}
+
+	got := sessions[0]
+	if got.Elapsed != 3*time.Second || len(got.Laps) != 1 || got.Laps[0] != time.Second {
+		t.Errorf("Sessions: unexpected session %+v\n", got)
+	}
+
+	if got.End.Sub(got.Start) != 3*time.Second {
+		t.Errorf("Sessions: got: %s - %s expected a 3s session\n", got.Start, got.End)
+	}
+}
+
+func TestArchive_Retention(t *testing.T) {
+	c := newClock()
+	dir := t.TempDir()
+
+	add := func(a *Archive, n int) {
+		for i := 0; i < n; i++ {
+			sw := Start(0, WithClock(c))
+			c.Advance(time.Duration(i+1) * time.Second)
+			sw.Stop()
+			if err := a.Add(sw); err != nil {
+				t.Fatalf("Add: error: %s\n", err)
+			}
+		}
+	}
+
+	count := func(a *Archive) int {
+		sessions, err := a.Sessions()
+		if err != nil {
+			t.Fatalf("Sessions: error: %s\n", err)
+		}
+		return len(sessions)
+	}
+
+	bySessions := NewArchive(filepath.Join(dir, "sessions.jsonl"), Retention{MaxSessions: 3}, c)
+	add(bySessions, 5)
+	if n := count(bySessions); n != 3 {
+		t.Errorf("MaxSessions: got: %d sessions expected: %d\n", n, 3)
+	}
+
+	sessions, _ := bySessions.Sessions()
+	if sessions[0].Elapsed != 3*time.Second {
+		t.Errorf("MaxSessions: the oldest sessions should be pruned, first is %s\n", sessions[0].Elapsed)
+	}
+
+	byAge := NewArchive(filepath.Join(dir, "age.jsonl"), Retention{MaxAge: time.Hour}, c)
+	add(byAge, 2)
+	c.Advance(2 * time.Hour)
+	add(byAge, 1)
+	if n := count(byAge); n != 1 {
+		t.Errorf("MaxAge: got: %d sessions expected: %d\n", n, 1)
+	}
+
+	c.Advance(2 * time.Hour)
+	if err := byAge.Prune(); err != nil {
+		t.Fatalf("Prune: error: %s\n", err)
+	}
+	if n := count(byAge); n != 0 {
+		t.Errorf("Prune: got: %d sessions expected: %d\n", n, 0)
+	}
+
+	bySize := NewArchive(filepath.Join(dir, "size.jsonl"), Retention{MaxSize: 512}, c)
+	add(bySize, 20)
+	info, err := os.Stat(filepath.Join(dir, "size.jsonl"))
+	if err != nil {
+		t.Fatalf("MaxSize: error: %s\n", err)
+	}
+	if info.Size() > 512 {
+		t.Errorf("MaxSize: got: %d bytes expected at most %d\n", info.Size(), 512)
+	}
+	if n := count(bySize); n == 0 || n == 20 {
+		t.Errorf("MaxSize: got: %d sessions\n", n)
+	}
+}
@@ -0,0 +1,71 @@
+package stopwatch
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// lockFreeClock is the clock of the lock-free ElapsedTime and the instant the
+// published start times are relative to, see WithLockFreeElapsed.
+type lockFreeClock struct {
+	clock  Clock
+	origin time.Time
+}
+
+// WithLockFreeElapsed makes ElapsedTime lock-free: it costs a single atomic
+// load and a reading of the clock, so it doesn't contend with the goroutine
+// driving the stopwatch, such as on very hot paths or with many concurrent
+// readers.
+func WithLockFreeElapsed() Option {
+	return func(s *Stopwatch) { s.lockFree = true }
+}
+
+// initLockFree sets up the clock of the lock-free ElapsedTime. It is called
+// once the options are applied.
+func (s *Stopwatch) initLockFree() {
+	atomic.StoreInt64(&s.published, 0)
+	if !s.lockFree {
+		s.lfClock.Store((*lockFreeClock)(nil))
+		return
+	}
+
+	c := clockOrSystem(s.clock)
+	s.lfClock.Store(&lockFreeClock{clock: c, origin: c.Now()})
+}
+
+// publish stores the state needed by the lock-free ElapsedTime in a single
+// int64, see WithLockFreeElapsed. The lowest bit is set while the stopwatch
+// runs, the other bits hold the start time relative to the origin of the
+// lockFreeClock, or the elapsed time of a stopped stopwatch. The lock must be
+// held.
+func (s *Stopwatch) publish() {
+	if !s.lockFree {
+		return
+	}
+
+	var v int64
+	switch {
+	case s.isReseted():
+	case s.stopped:
+		v = int64(s.stop.Sub(s.start)) << 1
+	default:
+		lf := s.lfClock.Load().(*lockFreeClock)
+		v = int64(s.start.Sub(lf.origin))<<1 | 1
+	}
+	atomic.StoreInt64(&s.published, v)
+}
+
+// loadElapsed returns the elapsed time from the published state, without
+// locking. It returns false if the stopwatch is not lock-free.
+func (s *Stopwatch) loadElapsed() (time.Duration, bool) {
+	lf, _ := s.lfClock.Load().(*lockFreeClock)
+	if lf == nil {
+		return 0, false
+	}
+
+	v := atomic.LoadInt64(&s.published)
+	if v&1 == 0 {
+		return time.Duration(v >> 1), true
+	}
+	return lf.clock.Now().Sub(lf.origin.Add(time.Duration(v >> 1))), true
+}
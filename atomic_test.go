@@ -0,0 +1,79 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_LockFreeElapsed(t *testing.T) {
+	c := newClock()
+	sw := New(WithClock(c), WithLockFreeElapsed())
+	if sw.ElapsedTime() != 0 {
+		t.Errorf("ElapsedTime: got: %s expected: 0s\n", sw.ElapsedTime())
+	}
+
+	sw.Start(0)
+	c.Advance(time.Second)
+	if got := sw.ElapsedTime(); got != time.Second {
+		t.Errorf("ElapsedTime: got: %s expected: 1s\n", got)
+	}
+
+	sw.Stop()
+	c.Advance(time.Second)
+	if got := sw.ElapsedTime(); got != time.Second {
+		t.Errorf("ElapsedTime: got: %s expected: 1s after Stop\n", got)
+	}
+
+	sw.Start(0)
+	c.Advance(time.Second)
+	sw.AddElapsed(time.Second)
+	if got := sw.ElapsedTime(); got != 3*time.Second {
+		t.Errorf("ElapsedTime: got: %s expected: 3s\n", got)
+	}
+
+	sw.Reset()
+	if got := sw.ElapsedTime(); got != 0 {
+		t.Errorf("ElapsedTime: got: %s expected: 0s after Reset\n", got)
+	}
+}
+
+func TestStopwatch_LockFreeElapsedConcurrent(t *testing.T) {
+	sw := Start(0, WithLockFreeElapsed())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				if sw.ElapsedTime() < 0 {
+					t.Error("ElapsedTime: got a negative elapsed time")
+					return
+				}
+			}
+		}()
+	}
+
+	for j := 0; j < 1000; j++ {
+		sw.Stop()
+		sw.Start(0)
+	}
+	wg.Wait()
+}
+
+func TestStopwatch_LockFreeElapsedAllocs(t *testing.T) {
+	sw := Start(0, WithLockFreeElapsed())
+
+	allocs := testing.AllocsPerRun(100, func() {
+		sw.Stop()
+		sw.Start(0)
+		sw.ElapsedTime()
+	})
+	if allocs != 0 {
+		t.Errorf("ElapsedTime: expected no allocations per state change, got %v\n", allocs)
+	}
+}
@@ -0,0 +1,45 @@
+package stopwatch
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeAuthority is an external reference time source, such as a PTP or NTP
+// daemon. It is consulted when wall clock timestamps are serialized, to anchor
+// them to the reference time instead of the local clock. This keeps the
+// timestamps of stopwatches on different machines comparable.
+type TimeAuthority interface {
+	// Offset returns the offset of the reference time from the local clock,
+	// the reference time is the local time plus the offset.
+	Offset() (time.Duration, error)
+}
+
+// TimeAuthorityFunc is an adapter to use a function as a TimeAuthority.
+type TimeAuthorityFunc func() (time.Duration, error)
+
+// Offset calls f.
+func (f TimeAuthorityFunc) Offset() (time.Duration, error) { return f() }
+
+// WithTimeAuthority anchors the wall clock timestamps written by Report,
+// WriteReportFile, ExportLineProtocol and Archive to the given time
+// authority. It is consulted once per export, an error of the authority
+// fails the export. Durations are not affected.
+func WithTimeAuthority(a TimeAuthority) Option {
+	return func(s *Stopwatch) { s.authority = a }
+}
+
+// anchorOffset returns the offset to add to serialized wall clock
+// timestamps. It must not be called with the lock held, as the authority may
+// block.
+func (s *Stopwatch) anchorOffset() (time.Duration, error) {
+	if s.authority == nil {
+		return 0, nil
+	}
+
+	offset, err := s.authority.Offset()
+	if err != nil {
+		return 0, fmt.Errorf("stopwatch: time authority: %w", err)
+	}
+	return offset, nil
+}
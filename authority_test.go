@@ -0,0 +1,78 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_WithTimeAuthority(t *testing.T) {
+	c := newClock()
+	start := c.Now()
+	authority := TimeAuthorityFunc(func() (time.Duration, error) { return time.Hour, nil })
+
+	sw := Start(0, WithClock(c), WithTimeAuthority(authority))
+	c.Advance(time.Second)
+	sw.Lap()
+	sw.Stop()
+
+	var buf bytes.Buffer
+	if err := sw.Report(&buf, ReportJSON); err != nil {
+		t.Fatalf("Report: error: %s\n", err)
+	}
+
+	var r struct {
+		Start time.Time `json:"start"`
+	}
+	json.Unmarshal(buf.Bytes(), &r)
+	if !r.Start.Equal(start.Add(time.Hour)) {
+		t.Errorf("Report: got: %s expected: %s\n", r.Start, start.Add(time.Hour))
+	}
+
+	buf.Reset()
+	if err := sw.ExportLineProtocol(&buf, "m", nil); err != nil {
+		t.Fatalf("ExportLineProtocol: error: %s\n", err)
+	}
+	stamp := start.Add(time.Hour + time.Second).UnixNano()
+	if !strings.Contains(buf.String(), " "+itoa(stamp)+"\n") {
+		t.Errorf("ExportLineProtocol: expected timestamp %d in:\n%s\n", stamp, buf.String())
+	}
+
+	a := NewArchive(filepath.Join(t.TempDir(), "sessions.jsonl"), Retention{}, c)
+	if err := a.Add(sw); err != nil {
+		t.Fatalf("Add: error: %s\n", err)
+	}
+	sessions, _ := a.Sessions()
+	if len(sessions) != 1 || !sessions[0].Start.Equal(start.Add(time.Hour)) {
+		t.Errorf("Archive: unexpected sessions %+v\n", sessions)
+	}
+	if sessions[0].Elapsed != time.Second {
+		t.Errorf("Archive: got: %s expected: %s\n", sessions[0].Elapsed, time.Second)
+	}
+}
+
+func TestStopwatch_WithTimeAuthorityError(t *testing.T) {
+	errDown := errors.New("daemon down")
+	authority := TimeAuthorityFunc(func() (time.Duration, error) { return 0, errDown })
+	sw := Start(0, WithTimeAuthority(authority))
+
+	var buf bytes.Buffer
+	if err := sw.Report(&buf, ReportJSON); !errors.Is(err, errDown) {
+		t.Errorf("Report: got: %v expected: %v\n", err, errDown)
+	}
+
+	if err := sw.ExportLineProtocol(&buf, "m", nil); !errors.Is(err, errDown) {
+		t.Errorf("ExportLineProtocol: got: %v expected: %v\n", err, errDown)
+	}
+
+	if buf.Len() != 0 {
+		t.Errorf("Report: nothing should be written on error, got:\n%s\n", buf.String())
+	}
+}
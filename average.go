@@ -0,0 +1,77 @@
+package stopwatch
+
+import "time"
+
+// maxEWMAs is the number of alphas whose averages are updated on each lap, see
+// EWMA.
+const maxEWMAs = 4
+
+// MovingAverage returns the average duration of the latest window stored
+// laps, or of all stored laps if there are fewer. It returns zero if there
+// are no laps or window is zero or less.
+// Example : s.MovingAverage(10)
+func (s *Stopwatch) MovingAverage(window int) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := len(s.laps)
+	if window < n {
+		n = window
+	}
+	if n <= 0 {
+		return 0
+	}
+
+	var total time.Duration
+	for i := 1; i <= n; i++ {
+		total += s.laps[(s.lapHead-i+len(s.laps))%len(s.laps)].Duration
+	}
+	return total / time.Duration(n)
+}
+
+// EWMA returns the exponentially weighted moving average of the lap
+// durations, where alpha in (0, 1] is the weight of the latest lap. The
+// first call for an alpha computes the average over the stored laps, from
+// then on it is updated on each lap. Only the first four alphas are updated,
+// the averages of any other are computed over the stored laps on each call.
+// It returns zero if there are no laps or alpha is out of range.
+// Example : s.EWMA(0.2)
+func (s *Stopwatch) EWMA(alpha float64) time.Duration {
+	if alpha <= 0 || alpha > 1 {
+		return 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	avg, ok := s.ewma[alpha]
+	if !ok {
+		for i, lap := range s.lapRecords() {
+			avg = nextEWMA(avg, alpha, lap.Duration, i == 0)
+		}
+		if len(s.ewma) < maxEWMAs {
+			if s.ewma == nil {
+				s.ewma = make(map[float64]float64)
+			}
+			s.ewma[alpha] = avg
+		}
+	}
+	return time.Duration(avg)
+}
+
+// updateEWMA adds d to the moving averages requested with EWMA, before the
+// lap is counted. The lock must be held.
+func (s *Stopwatch) updateEWMA(d time.Duration) {
+	for alpha, avg := range s.ewma {
+		s.ewma[alpha] = nextEWMA(avg, alpha, d, s.lapStats.Count == 0)
+	}
+}
+
+// nextEWMA returns the average avg updated with d. The first value is taken
+// as is.
+func nextEWMA(avg, alpha float64, d time.Duration, first bool) float64 {
+	if first {
+		return float64(d)
+	}
+	return alpha*float64(d) + (1-alpha)*avg
+}
@@ -0,0 +1,99 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStopwatch_MovingAverage(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c), WithMaxLaps(4))
+	if avg := sw.MovingAverage(3); avg != 0 {
+		t.Errorf("MovingAverage: got: %s expected: 0s\n", avg)
+	}
+
+	for i := 1; i <= 6; i++ {
+		c.Advance(time.Duration(i) * time.Second)
+		sw.Lap()
+	}
+
+	tests := []struct {
+		window   int
+		expected time.Duration
+	}{
+		{1, 6 * time.Second},
+		{3, 5 * time.Second},
+		{10, 4500 * time.Millisecond}, // only 4 laps are stored
+		{0, 0},
+	}
+	for _, test := range tests {
+		if avg := sw.MovingAverage(test.window); avg != test.expected {
+			t.Errorf("MovingAverage(%d): got: %s expected: %s\n", test.window, avg, test.expected)
+		}
+	}
+}
+
+func TestStopwatch_EWMA(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+
+	c.Advance(time.Second)
+	sw.Lap()
+	c.Advance(3 * time.Second)
+	sw.Lap()
+
+	// computed over the stored laps on the first call
+	if avg := sw.EWMA(0.5); avg != 2*time.Second {
+		t.Errorf("EWMA: got: %s expected: 2s\n", avg)
+	}
+
+	// then updated on each lap
+	c.Advance(4 * time.Second)
+	sw.Lap()
+	if avg := sw.EWMA(0.5); avg != 3*time.Second {
+		t.Errorf("EWMA: got: %s expected: 3s\n", avg)
+	}
+
+	sw.ClearLaps()
+	if avg := sw.EWMA(0.5); avg != 0 {
+		t.Errorf("EWMA: got: %s expected: 0s after ClearLaps\n", avg)
+	}
+	c.Advance(time.Second)
+	sw.Lap()
+	if avg := sw.EWMA(0.5); avg != time.Second {
+		t.Errorf("EWMA: got: %s expected: 1s\n", avg)
+	}
+
+	if avg := sw.EWMA(1.5); avg != 0 {
+		t.Errorf("EWMA: got: %s expected: 0s for an invalid alpha\n", avg)
+	}
+}
+
+func TestStopwatch_EWMAMaxAlphas(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	c.Advance(time.Second)
+	sw.Lap()
+
+	for i := 1; i <= 100; i++ {
+		if avg := sw.EWMA(float64(i) / 100); avg != time.Second {
+			t.Fatalf("EWMA: got: %s expected: 1s\n", avg)
+		}
+	}
+
+	sw.mu.Lock()
+	n := len(sw.ewma)
+	sw.mu.Unlock()
+	if n != maxEWMAs {
+		t.Errorf("EWMA: got: %d updated alphas expected: %d\n", n, maxEWMAs)
+	}
+
+	c.Advance(3 * time.Second)
+	sw.Lap()
+	if avg := sw.EWMA(0.5); avg != 2*time.Second {
+		t.Errorf("EWMA: got: %s expected: 2s for an alpha computed on each call\n", avg)
+	}
+}
@@ -0,0 +1,40 @@
+package stopwatch
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// WriteBenchFormat writes the laps in the Go benchmark result format, one
+// line per lap, so that runs can be compared with benchstat. Every lap is
+// reported as a single iteration. The name is prefixed with "Benchmark" if
+// it isn't already and white space is replaced by underscores.
+// Example output: BenchmarkParse 1 1234567 ns/op
+func (s *Stopwatch) WriteBenchFormat(w io.Writer, name string) error {
+	name = benchName(name)
+
+	var b strings.Builder
+	for _, lap := range s.Laps() {
+		b.WriteString(name + " 1 " + strconv.FormatInt(int64(lap), 10) + " ns/op\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// benchName returns name as a valid benchmark name.
+func benchName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return '_'
+		}
+		return r
+	}, name)
+
+	if !strings.HasPrefix(name, "Benchmark") {
+		name = "Benchmark" + name
+	}
+	return name
+}
@@ -0,0 +1,33 @@
+package stopwatch
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_WriteBenchFormat(t *testing.T) {
+	sw := Start(0)
+	sw.laps = []LapRecord{
+		{Seq: 1, Duration: 1500 * time.Microsecond},
+		{Seq: 2, Duration: 2 * time.Millisecond},
+	}
+
+	var buf bytes.Buffer
+	if err := sw.WriteBenchFormat(&buf, "parse file"); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	expected := "Benchmarkparse_file 1 1500000 ns/op\nBenchmarkparse_file 1 2000000 ns/op\n"
+	if buf.String() != expected {
+		t.Errorf("WriteBenchFormat: got:\n%s\nexpected:\n%s\n", buf.String(), expected)
+	}
+
+	buf.Reset()
+	if err := sw.WriteBenchFormat(&buf, "BenchmarkParse"); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	if got := buf.String()[:len("BenchmarkParse ")]; got != "BenchmarkParse " {
+		t.Errorf("WriteBenchFormat: got name: %q expected: %q\n", got, "BenchmarkParse ")
+	}
+}
@@ -0,0 +1,114 @@
+package stopwatch
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Budget is the time budget of a section and the time actually spent in it.
+type Budget struct {
+	Name   string // section path
+	Budget time.Duration
+	Actual time.Duration // total of all runs of the section so far
+}
+
+// Over reports whether the section took longer than its budget.
+func (b Budget) Over() bool {
+	return b.Actual > b.Budget
+}
+
+func (b Budget) String() string {
+	if b.Over() {
+		return fmt.Sprintf("%s: %s of %s, over by %s", b.Name, b.Actual, b.Budget, b.Actual-b.Budget)
+	}
+	return fmt.Sprintf("%s: %s of %s", b.Name, b.Actual, b.Budget)
+}
+
+// SetBudget sets the time budget of the section with the given slash
+// separated path, such as "request/db". A budget of zero or less removes it.
+// Budgets are kept across resets. The end of a section taking it over budget
+// delivers an EventBudget. See Expectations to check a finished
+// session against limits loaded from a file.
+// Example : s.SetBudget("db", 50*time.Millisecond)
+func (s *Stopwatch) SetBudget(name string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d <= 0 {
+		delete(s.budgets, name)
+		return
+	}
+
+	if s.budgets == nil {
+		s.budgets = make(map[string]time.Duration)
+	}
+	s.budgets[name] = d
+}
+
+// checkBudget appends an EventBudget to events if the section c just ended
+// made its sections take longer than their budget. The lock must be held.
+func (s *Stopwatch) checkBudget(events []Event, c *Section) []Event {
+	path := c.path()
+	budget, ok := s.budgets[path]
+	if !ok {
+		return events
+	}
+
+	total := sectionTotal(s.sections, path)
+	if total <= budget || total-c.Elapsed() > budget {
+		return events
+	}
+
+	e := s.breach(EventBudget, c)
+	e.Section, e.Duration = path, total
+	return append(events, e)
+}
+
+// sectionTotal returns the time spent in the sections with the given path.
+func sectionTotal(sections []*Section, path string) time.Duration {
+	var total time.Duration
+	for _, c := range sections {
+		if c.path() == path {
+			total += c.Elapsed()
+		}
+		total += sectionTotal(c.Children, path)
+	}
+	return total
+}
+
+// BudgetReport returns the budgets with the time spent in their sections,
+// sorted by name. The time of a section opened more than once is summed up,
+// open sections count with their time so far.
+func (s *Stopwatch) BudgetReport() []Budget {
+	r := s.report(0)
+
+	s.mu.Lock()
+	budgets := make([]Budget, 0, len(s.budgets))
+	for name, d := range s.budgets {
+		budgets = append(budgets, Budget{Name: name, Budget: d})
+	}
+	s.mu.Unlock()
+
+	sort.Slice(budgets, func(i, j int) bool { return budgets[i].Name < budgets[j].Name })
+	for i := range budgets {
+		for _, c := range r.Sections {
+			if c.Name == budgets[i].Name {
+				budgets[i].Actual += c.Elapsed
+			}
+		}
+	}
+	return budgets
+}
+
+// OverBudget returns the sections that took longer than their budget, sorted
+// by name. It returns nil if all sections are within their budget.
+func (s *Stopwatch) OverBudget() []Violation {
+	var violations []Violation
+	for _, b := range s.BudgetReport() {
+		if b.Over() {
+			violations = append(violations, Violation{Name: b.Name, Max: b.Budget, Elapsed: b.Actual})
+		}
+	}
+	return violations
+}
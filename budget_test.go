@@ -0,0 +1,53 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStopwatch_Budget(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	sw.SetBudget("request/db", 50*time.Millisecond)
+	sw.SetBudget("request/render", 20*time.Millisecond)
+	sw.SetBudget("request/cache", 5*time.Millisecond)
+	sw.SetBudget("request/cache", 0)
+
+	end := sw.Section("request")
+	for i := 0; i < 2; i++ {
+		endDB := sw.Section("db")
+		c.Advance(30 * time.Millisecond)
+		endDB()
+	}
+	endRender := sw.Section("render")
+	c.Advance(10 * time.Millisecond)
+	endRender()
+	end()
+
+	report := sw.BudgetReport()
+	expected := []string{
+		"request/db: 60ms of 50ms, over by 10ms",
+		"request/render: 10ms of 20ms",
+	}
+	if len(report) != len(expected) {
+		t.Fatalf("BudgetReport: got: %v expected: %v\n", report, expected)
+	}
+	for i, b := range report {
+		if b.String() != expected[i] {
+			t.Errorf("BudgetReport: got: %s expected: %s\n", b, expected[i])
+		}
+	}
+
+	over := sw.OverBudget()
+	if len(over) != 1 || over[0].Name != "request/db" || over[0].Elapsed != 60*time.Millisecond {
+		t.Errorf("OverBudget: got: %v expected: request/db\n", over)
+	}
+
+	sw.Reset()
+	if report := sw.BudgetReport(); len(report) != 2 || report[0].Actual != 0 {
+		t.Errorf("BudgetReport: got: %v expected budgets without time after Reset\n", report)
+	}
+}
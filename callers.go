@@ -0,0 +1,59 @@
+package stopwatch
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// WithCallers records the file:line of the callers of Start, Restart, Lap and
+// Section, see Caller, LapRecord.Caller and Section.Caller. They show up in
+// String, the reports and the trace export, which tells apart many anonymous
+// stopwatches in a dump. It costs a stack walk per call, so it is meant for
+// debugging.
+func WithCallers() Option {
+	return func(s *Stopwatch) { s.callers = true }
+}
+
+// Caller returns the file:line at which the session was started, see
+// WithCallers. It is empty if callers are not recorded.
+func (s *Stopwatch) Caller() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.caller
+}
+
+// callerOf returns the call site outside of this package if s records
+// callers, see WithCallers.
+func (s *Stopwatch) callerOf() string {
+	if !s.callers {
+		return ""
+	}
+	return callSite()
+}
+
+// pkgPrefix prefixes the names of the functions of this package, such as
+// "github.com/fatih/stopwatch.". It is read from the binary, so it stays right
+// in a fork or a vendored copy.
+var pkgPrefix = func() string {
+	pc, _, _, _ := runtime.Caller(0)
+	name := runtime.FuncForPC(pc).Name()
+	slash := strings.LastIndexByte(name, '/') + 1
+	return name[:slash+strings.IndexByte(name[slash:], '.')+1]
+}()
+
+// callSite returns the file:line of the first caller outside of this
+// package, or "unknown".
+func callSite() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		f, more := frames.Next()
+		if !strings.HasPrefix(f.Function, pkgPrefix) || strings.HasSuffix(f.File, "_test.go") {
+			return f.File + ":" + strconv.Itoa(f.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
@@ -0,0 +1,49 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestStopwatch_WithCallers(t *testing.T) {
+	sw := Start(0, WithCallers())
+	sw.Lap()
+	sw.Section("load")()
+
+	checks := map[string]string{
+		"Caller":         sw.Caller(),
+		"LapRecord":      sw.LapRecords()[0].Caller,
+		"Section.Caller": sw.Sections()[0].Caller,
+	}
+	for name, caller := range checks {
+		if !strings.Contains(caller, "callers_test.go:") {
+			t.Errorf("%s: got: %q expected: callers_test.go\n", name, caller)
+		}
+	}
+
+	if !strings.Contains(sw.String(), "caller: ") {
+		t.Errorf("String: got: %s expected the caller\n", sw.String())
+	}
+
+	var buf bytes.Buffer
+	if err := sw.ExportTraceJSON(&buf); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	if n := strings.Count(buf.String(), "callers_test.go:"); n != 3 {
+		t.Errorf("ExportTraceJSON: got: %d callers expected: 3\n", n)
+	}
+
+	if New().Caller() != "" || Start(0).Caller() != "" {
+		t.Error("Caller: callers should not be recorded without WithCallers")
+	}
+}
+
+func TestPkgPrefix(t *testing.T) {
+	if pkgPrefix != "github.com/fatih/stopwatch." {
+		t.Errorf("got: %q expected: the import path of the package\n", pkgPrefix)
+	}
+}
@@ -0,0 +1,238 @@
+package stopwatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// checkpointVersion is the version of the checkpoint format.
+const checkpointVersion = 1
+
+// checkpoint is the persisted state of a stopwatch.
+type checkpoint struct {
+	Version  int                 `json:"version"`
+	State    string              `json:"state"`
+	Elapsed  time.Duration       `json:"elapsed_ns"`
+	SinceLap time.Duration       `json:"since_lap_ns"`
+	Seq      uint64              `json:"seq"`
+	Laps     []LapRecord         `json:"laps,omitempty"`
+	LapStats LapStats            `json:"lap_stats"`
+	LapBase  time.Duration       `json:"lap_base_ns,omitempty"`
+	Sections []checkpointSection `json:"sections,omitempty"`
+	Total    int                 `json:"total,omitempty"`
+	Done     int                 `json:"done,omitempty"`
+	Ticks    int64               `json:"ticks,omitempty"`
+	Tags     map[string]string   `json:"tags,omitempty"`
+}
+
+type checkpointSection struct {
+	Name     string              `json:"name"`
+	Start    time.Time           `json:"start"`
+	End      *time.Time          `json:"end,omitempty"`
+	Elapsed  time.Duration       `json:"elapsed_ns,omitempty"` // of open sections
+	Offset   time.Duration       `json:"offset_ns,omitempty"`
+	Children []checkpointSection `json:"children,omitempty"`
+}
+
+// WriteCheckpoint writes the state of the stopwatch as JSON to w: the elapsed
+// time, the laps, the sections, the progress and the tags. It can be read
+// back with ReadCheckpoint.
+func (s *Stopwatch) WriteCheckpoint(w io.Writer) error {
+	s.mu.Lock()
+	cp := checkpoint{
+		Version:  checkpointVersion,
+		State:    s.state(),
+		Elapsed:  s.elapsed(),
+		Seq:      s.seq,
+		Laps:     s.lapRecords(),
+		LapStats: s.lapStats,
+		LapBase:  s.lapBase,
+		Sections: checkpointSections(s.sections),
+		Total:    s.total,
+		Done:     s.done,
+		Ticks:    s.ticks,
+		Tags:     mergeTags(s.tags, nil),
+	}
+	switch {
+	case s.isStopped():
+		cp.SinceLap = s.stop.Sub(s.lap)
+	case s.isRunning():
+		cp.SinceLap = s.since(s.lap)
+	}
+	s.mu.Unlock()
+
+	return json.NewEncoder(w).Encode(cp)
+}
+
+// ReadCheckpoint creates a new stopwatch with the options from a checkpoint
+// written by WriteCheckpoint. A running stopwatch resumes where it was
+// checkpointed, the time in between is not counted. Sections are restored
+// with their wall clock timestamps, open sections go on from their elapsed
+// time like the stopwatch.
+func ReadCheckpoint(r io.Reader, opts ...Option) (*Stopwatch, error) {
+	var cp checkpoint
+	if err := json.NewDecoder(r).Decode(&cp); err != nil {
+		return nil, err
+	}
+	if cp.Version != checkpointVersion {
+		return nil, fmt.Errorf("stopwatch: unknown checkpoint version %d", cp.Version)
+	}
+
+	s := Resume(cp.Elapsed, cp.State == "running", opts...)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tags = mergeTags(s.tags, cp.Tags)
+	if cp.State == "reset" || !Enabled {
+		return s, nil
+	}
+
+	s.seq = cp.Seq
+	for _, lap := range cp.Laps {
+		s.addLap(lap)
+	}
+	s.lapStats, s.lapBase = cp.LapStats, cp.LapBase
+	if s.isStopped() {
+		s.lap = s.stop.Add(-cp.SinceLap)
+	} else {
+		s.lap = s.now().Add(-cp.SinceLap)
+	}
+
+	s.sections = s.restoreSections(cp.Sections, nil)
+	s.total, s.done, s.ticks = cp.Total, cp.Done, cp.Ticks
+	return s, nil
+}
+
+// Save writes a checkpoint of the stopwatch to the file at path, see
+// WriteCheckpoint. The file is replaced atomically, a crash never leaves a
+// partially written checkpoint behind. It is compressed if the extension
+// belongs to a registered Compressor, such as "job.json.gz".
+func (s *Stopwatch) Save(path string) error {
+	c, _ := compressorFor(path)
+	return writeFileAtomic(path, func(w io.Writer) error {
+		return compressTo(w, c, s.WriteCheckpoint)
+	})
+}
+
+// Load creates a new stopwatch with the options from the checkpoint file at
+// path written by Save, see ReadCheckpoint.
+func Load(path string, opts ...Option) (*Stopwatch, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if c, _ := compressorFor(path); c != nil {
+		cr, err := c.NewReader(f)
+		if err != nil {
+			return nil, err
+		}
+		defer cr.Close()
+		r = cr
+	}
+
+	return ReadCheckpoint(r, opts...)
+}
+
+// AutoSave saves a checkpoint of the stopwatch to the file at path every
+// interval, so long running jobs survive a crash with their timing. The
+// returned function saves a final checkpoint, ends the saving and returns
+// the first error encountered. It can be called more than once.
+// Example : defer s.AutoSave("job.json", time.Minute)()
+func (s *Stopwatch) AutoSave(path string, interval time.Duration) (stop func() error) {
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	var firstErr error
+
+	save := func() {
+		if err := s.Save(path); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	var once sync.Once
+	end := func() {
+		once.Do(func() { close(done) })
+		<-finished
+	}
+	untrack := s.addTask(end)
+
+	go func() {
+		defer close(finished)
+		defer untrack()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				save()
+				return
+			case <-ticker.C:
+				save()
+			}
+		}
+	}()
+
+	return func() error {
+		end()
+		return firstErr
+	}
+}
+
+func checkpointSections(sections []*Section) []checkpointSection {
+	if len(sections) == 0 {
+		return nil
+	}
+
+	out := make([]checkpointSection, len(sections))
+	for i, c := range sections {
+		out[i] = checkpointSection{
+			Name:     c.Name,
+			Start:    c.Start,
+			Offset:   c.offset,
+			Children: checkpointSections(c.Children),
+		}
+		if c.IsOpen() {
+			out[i].Elapsed = c.Elapsed()
+		} else {
+			end := c.End
+			out[i].End = &end
+		}
+	}
+	return out
+}
+
+// restoreSections rebuilds the sections of a checkpoint and makes the
+// innermost open section the current one. The lock must be held.
+func (s *Stopwatch) restoreSections(sections []checkpointSection, parent *Section) []*Section {
+	if len(sections) == 0 {
+		return nil
+	}
+
+	out := make([]*Section, len(sections))
+	for i, cs := range sections {
+		c := &Section{Name: cs.Name, Start: cs.Start, parent: parent, offset: cs.Offset, clock: s.clock}
+		if cs.End != nil {
+			c.End = *cs.End
+		} else {
+			// the start is rebuilt from the clock, so that the section
+			// measures its time with the monotonic clock reading
+			if cs.Elapsed > 0 {
+				c.Start = s.now().Add(-cs.Elapsed)
+			}
+			s.section = c
+		}
+		c.Children = s.restoreSections(cs.Children, c)
+		out[i] = c
+	}
+	return out
+}
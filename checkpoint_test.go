@@ -0,0 +1,115 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_Checkpoint(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	sw.SetTotal(10)
+
+	c.Advance(time.Second)
+	sw.Lap()
+	end := sw.Section("load")
+	sw.Section("parse")()
+	c.Advance(2 * time.Second)
+	sw.Lap()
+	sw.Advance(3)
+	sw.Tick(7)
+	c.Advance(500 * time.Millisecond)
+
+	var buf bytes.Buffer
+	if err := sw.WriteCheckpoint(&buf); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	c.Advance(time.Hour) // downtime is not counted
+	got, err := ReadCheckpoint(&buf, WithClock(c))
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	if e := got.ElapsedTime(); e != 3500*time.Millisecond || got.IsStopped() {
+		t.Errorf("ReadCheckpoint: got: %s expected a running stopwatch at 3.5s\n", e)
+	}
+	if !reflect.DeepEqual(got.LapRecords(), sw.LapRecords()) || got.LapStats() != sw.LapStats() {
+		t.Errorf("ReadCheckpoint: got laps: %v expected: %v\n", got.LapRecords(), sw.LapRecords())
+	}
+	if d := got.SinceLap(); d != 500*time.Millisecond {
+		t.Errorf("ReadCheckpoint: got: %s since the lap expected: 500ms\n", d)
+	}
+	if p := got.Progress(); p.Done != 3 || p.Total != 10 || got.Ticks() != 7 {
+		t.Errorf("ReadCheckpoint: got progress: %+v and %d ticks\n", p, got.Ticks())
+	}
+
+	sections := got.Sections()
+	if len(sections) != 1 || !sections[0].IsOpen() || len(sections[0].Children) != 1 || sections[0].Children[0].IsOpen() {
+		t.Fatalf("ReadCheckpoint: unexpected sections %+v\n", sections)
+	}
+	end() // belongs to the old stopwatch
+	got.Section("index")()
+	if children := got.Sections()[0].Children; len(children) != 2 || children[1].Name != "index" {
+		t.Errorf("ReadCheckpoint: expected new sections to be nested in the open section\n")
+	}
+}
+
+func TestStopwatch_SaveLoad(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	c.Advance(time.Second)
+	sw.Lap()
+	c.Advance(time.Second)
+	sw.Stop()
+
+	for _, name := range []string{"job.json", "job.json.gz"} {
+		path := filepath.Join(t.TempDir(), name)
+		if err := sw.Save(path); err != nil {
+			t.Fatalf("error: %s\n", err)
+		}
+
+		got, err := Load(path, WithClock(c))
+		if err != nil {
+			t.Fatalf("error: %s\n", err)
+		}
+
+		c.Advance(time.Minute)
+		if e := got.ElapsedTime(); e != 2*time.Second || !got.IsStopped() || len(got.Laps()) != 1 {
+			t.Errorf("Load %s: got: %s and %v expected a stopped stopwatch at 2s\n", name, e, got.Laps())
+		}
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Load: expected an error for a missing file")
+	}
+}
+
+func TestStopwatch_AutoSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "job.json")
+	sw := Start(0)
+
+	stop := sw.AutoSave(path, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	if err := stop(); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	if err := stop(); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	if _, err := Load(path); err != nil {
+		t.Errorf("AutoSave: got: %s expected a checkpoint\n", err)
+	}
+
+	stop = sw.AutoSave(filepath.Join(t.TempDir(), "missing", "job.json"), time.Hour)
+	if err := stop(); err == nil {
+		t.Error("AutoSave: expected an error for a missing directory")
+	}
+}
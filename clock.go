@@ -0,0 +1,83 @@
+package stopwatch
+
+import "time"
+
+// Clock is the source of time of a Stopwatch. Providing a custom clock is
+// useful to drive a stopwatch in tests or simulations. A clock following the
+// wall clock should return times with a monotonic clock reading, like
+// time.Now, otherwise wall clock changes skew the measurements.
+type Clock interface {
+	Now() time.Time
+}
+
+// MonotonicClock is a Clock that also reads a monotonic time, which doesn't
+// jump when the wall time is changed. Stopwatches measure all durations on
+// the monotonic time of such a clock, only the wall time of their first
+// reading is taken from Now. The readings of time.Now carry a monotonic
+// clock reading for the same purpose, see the time package.
+type MonotonicClock interface {
+	Clock
+
+	// Monotonic returns the time elapsed since an arbitrary, fixed instant.
+	Monotonic() time.Duration
+}
+
+// systemClock is the default Clock backed by time.Now.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// clockOrSystem returns c or the system clock if c is nil.
+func clockOrSystem(c Clock) Clock {
+	if c == nil {
+		return systemClock{}
+	}
+	return c
+}
+
+// WithClock sets the clock of the stopwatch. A nil clock uses the system
+// clock.
+func WithClock(c Clock) Option {
+	return func(s *Stopwatch) { s.clock = c }
+}
+
+// monotonicClock reads a MonotonicClock like time.Now: its readings are the
+// wall time of the first reading plus the monotonic time elapsed since.
+type monotonicClock struct {
+	clock MonotonicClock
+	wall  time.Time
+	mono  time.Duration
+}
+
+func newMonotonicClock(c MonotonicClock) *monotonicClock {
+	return &monotonicClock{clock: c, wall: c.Now(), mono: c.Monotonic()}
+}
+
+func (c *monotonicClock) Now() time.Time {
+	return c.wall.Add(c.clock.Monotonic() - c.mono)
+}
+
+// scaledClock is a Clock that advances at a multiple of its underlying clock,
+// starting from the time it was created.
+type scaledClock struct {
+	clock  Clock
+	factor float64
+	origin time.Time
+}
+
+func (c *scaledClock) Now() time.Time {
+	d := c.clock.Now().Sub(c.origin)
+	return c.origin.Add(time.Duration(float64(d) * c.factor))
+}
+
+// WithSpeed makes the time of the stopwatch advance at factor times the speed
+// of its clock, such as 60 for a simulation where a second is a minute, or 0
+// for a frozen stopwatch. It applies to the clock set with WithClock as well,
+// regardless of the order of the options.
+func WithSpeed(factor float64) Option {
+	return func(s *Stopwatch) { s.speed, s.scaled = factor, true }
+}
+
+func (s *Stopwatch) now() time.Time { return clockOrSystem(s.clock).Now() }
+
+func (s *Stopwatch) since(t time.Time) time.Duration { return s.now().Sub(t) }
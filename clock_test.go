@@ -0,0 +1,79 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStopwatch_WithClock(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+
+	c.Advance(10 * time.Second)
+	if lap := sw.Lap(); lap != 10*time.Second {
+		t.Errorf("WithClock: got: %s expected: %s\n", lap, 10*time.Second)
+	}
+
+	end := sw.Section("work")
+	c.Advance(5 * time.Second)
+	end()
+	sw.Stop()
+	c.Advance(time.Hour)
+
+	if e := sw.ElapsedTime(); e != 15*time.Second {
+		t.Errorf("WithClock: got: %s expected: %s\n", e, 15*time.Second)
+	}
+
+	if d := sw.Sections()[0].Elapsed(); d != 5*time.Second {
+		t.Errorf("WithClock: got: %s expected: %s\n", d, 5*time.Second)
+	}
+}
+
+func TestStopwatch_WithClockSameInstant(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	sw.Stop()
+
+	if !sw.IsStopped() {
+		t.Fatal("Stop: a stop at the start instant should stop the stopwatch")
+	}
+
+	sw.Start(0)
+	if sw.IsStopped() {
+		t.Error("Start: got: stopped expected: running")
+	}
+
+	sw.Stop()
+	c.Advance(time.Second)
+	if e := sw.ElapsedTime(); e != 0 {
+		t.Errorf("Stop: got: %s expected: 0s\n", e)
+	}
+}
+
+func TestStopwatch_WithSpeed(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithSpeed(60), WithClock(c))
+
+	c.Advance(time.Second)
+	if e := sw.ElapsedTime(); e != time.Minute {
+		t.Errorf("WithSpeed: got: %s expected: 1m0s\n", e)
+	}
+
+	if lap := sw.Lap(); lap != time.Minute {
+		t.Errorf("WithSpeed: got lap: %s expected: 1m0s\n", lap)
+	}
+
+	frozen := Start(0, WithClock(c), WithSpeed(0))
+	c.Advance(time.Second)
+	if e := frozen.ElapsedTime(); e != 0 {
+		t.Errorf("WithSpeed: got: %s expected a frozen stopwatch\n", e)
+	}
+
+	frozen.Stop()
+	if !frozen.IsStopped() {
+		t.Error("WithSpeed: a frozen stopwatch should stop")
+	}
+}
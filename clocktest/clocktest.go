@@ -0,0 +1,65 @@
+// Package clocktest provides a manual clock for driving stopwatches in tests.
+// It implements the stopwatch.Clock interface:
+//
+//	c := clocktest.NewClock(time.Now())
+//	s := stopwatch.Start(0, stopwatch.WithClock(c))
+//	c.Advance(time.Second) // s.ElapsedTime() == time.Second
+package clocktest
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a clock that only moves when told to. It implements
+// stopwatch.MonotonicClock, so wall clock changes can be simulated with
+// Jump. It is safe for concurrent use.
+type Clock struct {
+	mu   sync.Mutex
+	t    time.Time
+	mono time.Duration
+}
+
+// NewClock returns a clock set to t.
+func NewClock(t time.Time) *Clock {
+	return &Clock{t: t}
+}
+
+// Now returns the current time of the clock.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+// Monotonic returns the total the clock was moved by with Advance and Set.
+func (c *Clock) Monotonic() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.mono
+}
+
+// Advance moves the clock forward by d, or backwards for a negative d.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.t = c.t.Add(d)
+	c.mono += d
+	c.mu.Unlock()
+}
+
+// Set sets the clock to t, like Advance by the difference to the current
+// time.
+func (c *Clock) Set(t time.Time) {
+	c.mu.Lock()
+	c.mono += t.Sub(c.t)
+	c.t = t
+	c.mu.Unlock()
+}
+
+// Jump moves the time returned by Now by d without moving the monotonic
+// time, like a step of the system clock by NTP or by hand.
+func (c *Clock) Jump(d time.Duration) {
+	c.mu.Lock()
+	c.t = c.t.Add(d)
+	c.mu.Unlock()
+}
@@ -0,0 +1,70 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package clocktest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fatih/stopwatch"
+)
+
+func TestClock(t *testing.T) {
+	start := time.Date(2014, 2, 10, 0, 0, 0, 0, time.UTC)
+	c := NewClock(start)
+	sw := stopwatch.Start(0, stopwatch.WithClock(c))
+
+	c.Advance(time.Second)
+	if e := sw.ElapsedTime(); e != time.Second {
+		t.Errorf("Advance: got: %s expected: 1s\n", e)
+	}
+
+	c.Set(start.Add(time.Minute))
+	if e := sw.ElapsedTime(); e != time.Minute {
+		t.Errorf("Set: got: %s expected: 1m0s\n", e)
+	}
+
+	if now := c.Now(); !now.Equal(start.Add(time.Minute)) {
+		t.Errorf("Now: got: %s expected: %s\n", now, start.Add(time.Minute))
+	}
+}
+
+func TestClock_StopWithoutAdvance(t *testing.T) {
+	c := NewClock(time.Date(2014, 2, 10, 0, 0, 0, 0, time.UTC))
+	sw := stopwatch.Start(0, stopwatch.WithClock(c))
+	sw.Stop()
+
+	if !sw.IsStopped() {
+		t.Fatal("Stop: got: running expected: stopped\n")
+	}
+
+	c.Advance(time.Second)
+	if e := sw.ElapsedTime(); e != 0 {
+		t.Errorf("Stop: got: %s expected: 0s\n", e)
+	}
+
+	sw.Start(0)
+	c.Advance(time.Second)
+	if e := sw.ElapsedTime(); e != time.Second {
+		t.Errorf("Start: got: %s expected: 1s\n", e)
+	}
+}
+
+func TestClock_Jump(t *testing.T) {
+	start := time.Date(2014, 2, 10, 0, 0, 0, 0, time.UTC)
+	c := NewClock(start)
+	sw := stopwatch.Start(0, stopwatch.WithClock(c))
+
+	c.Advance(time.Second)
+	c.Jump(-time.Hour)
+	if now := c.Now(); !now.Equal(start.Add(time.Second - time.Hour)) {
+		t.Errorf("Now: got: %s expected: %s\n", now, start.Add(time.Second-time.Hour))
+	}
+	if m := c.Monotonic(); m != time.Second {
+		t.Errorf("Monotonic: got: %s expected: 1s\n", m)
+	}
+	if e := sw.ElapsedTime(); e != time.Second {
+		t.Errorf("Jump: got: %s expected: 1s\n", e)
+	}
+}
@@ -0,0 +1,153 @@
+// Command stopwatch times shell commands and interactive sessions.
+//
+// Usage:
+//
+//	stopwatch run [-json] -- command [args...]
+//	stopwatch start|lap|stop|status|reset [-f file] [-json]
+//
+// run times a subprocess. Sending SIGUSR1 to stopwatch takes a lap. The
+// result is written to stderr, so it doesn't mix with the output of the
+// command. The exit code of the command is preserved.
+//
+// start, lap, stop and status drive a stopwatch whose state is kept in a
+// file between invocations, by default $STOPWATCH_FILE or stopwatch.json in
+// the temporary directory. reset removes the file.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/stopwatch"
+)
+
+const usage = `usage: stopwatch run [-json] -- command [args...]
+       stopwatch start|lap|stop|status|reset [-f file] [-json]
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "run":
+		err = run(args)
+	case "start", "lap", "stop", "status", "reset":
+		err = control(cmd, args, os.Stdout)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var exit *exec.ExitError
+	if errors.As(err, &exit) {
+		os.Exit(exit.ExitCode())
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "stopwatch:", err)
+		os.Exit(1)
+	}
+}
+
+// run times the command given in args.
+func run(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "write the result as JSON")
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		return errors.New("run: no command given")
+	}
+
+	cmd := exec.Command(fs.Arg(0), fs.Args()[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+
+	s := stopwatch.Start(0)
+	stop := notifyLap(func() { s.Lap() })
+	err := cmd.Run()
+	stop()
+	s.Stop()
+
+	if werr := write(os.Stderr, strings.Join(fs.Args(), " "), s, *asJSON); werr != nil {
+		return werr
+	}
+
+	return err
+}
+
+// control applies cmd to the stopwatch stored in the state file.
+func control(cmd string, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	path := fs.String("f", defaultStateFile(), "state file")
+	asJSON := fs.Bool("json", false, "write the result as JSON")
+	fs.Parse(args)
+
+	if cmd == "reset" {
+		if err := os.Remove(*path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	st, err := loadState(*path)
+	if err != nil {
+		return err
+	}
+
+	s := st.stopwatch()
+	if cmd == "status" {
+		if s.IsReseted() {
+			return errors.New("status: the stopwatch is not started")
+		}
+		return write(w, "stopwatch", s, *asJSON)
+	}
+
+	switch cmd {
+	case "start":
+		st.apply(s, stateStart)
+	case "lap":
+		if !st.apply(s, stateLap) {
+			return errors.New("lap: the stopwatch is not running")
+		}
+	case "stop":
+		if !st.apply(s, stateStop) {
+			return errors.New("stop: the stopwatch is not running")
+		}
+	}
+
+	if err := st.save(*path); err != nil {
+		return err
+	}
+
+	return write(w, "stopwatch", s, *asJSON)
+}
+
+// write writes the elapsed time and the laps of s.
+func write(w io.Writer, name string, s *stopwatch.Stopwatch, asJSON bool) error {
+	if asJSON {
+		return s.Report(w, stopwatch.ReportJSON)
+	}
+
+	for i, lap := range s.Laps() {
+		fmt.Fprintf(w, "lap %d: %s\n", i+1, lap)
+	}
+	_, err := fmt.Fprintf(w, "%s - elapsed: %s\n", name, s.ElapsedTime())
+	return err
+}
+
+func defaultStateFile() string {
+	if path := os.Getenv("STOPWATCH_FILE"); path != "" {
+		return path
+	}
+	return filepath.Join(os.TempDir(), "stopwatch.json")
+}
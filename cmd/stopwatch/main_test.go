@@ -0,0 +1,80 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestControl(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	flags := []string{"-f", path}
+
+	var buf bytes.Buffer
+	if err := control("status", flags, &buf); err == nil {
+		t.Errorf("status: expected an error for a stopwatch that is not started\n")
+	}
+
+	for _, cmd := range []string{"start", "lap", "lap", "stop"} {
+		if err := control(cmd, flags, &buf); err != nil {
+			t.Fatalf("%s: error: %s\n", cmd, err)
+		}
+	}
+
+	if err := control("lap", flags, &buf); err == nil {
+		t.Errorf("lap: expected an error for a stopped stopwatch\n")
+	}
+
+	buf.Reset()
+	if err := control("status", append(flags, "-json"), &buf); err != nil {
+		t.Fatalf("status: error: %s\n", err)
+	}
+
+	var report struct {
+		Laps []json.RawMessage `json:"laps"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("status: error: %s\n%s\n", err, buf.String())
+	}
+	if len(report.Laps) != 2 {
+		t.Errorf("status: got: %d laps expected: %d\n", len(report.Laps), 2)
+	}
+
+	if err := control("reset", flags, &buf); err != nil {
+		t.Fatalf("reset: error: %s\n", err)
+	}
+	if err := control("status", flags, &buf); err == nil {
+		t.Errorf("reset: the state should be removed\n")
+	}
+}
+
+func TestState_Replay(t *testing.T) {
+	start := time.Date(2014, 2, 10, 0, 0, 0, 0, time.UTC)
+	st := &state{
+		clock: &replayClock{},
+		Events: []stateEvent{
+			{stateStart, start},
+			{stateLap, start.Add(time.Second)},
+			{stateStop, start.Add(3 * time.Second)},
+			{stateStart, start.Add(time.Hour)},
+			{stateStop, start.Add(time.Hour + time.Second)},
+		},
+	}
+
+	s := st.stopwatch()
+	if got := s.ElapsedTime(); got != 4*time.Second {
+		t.Errorf("Replay: got: %s expected: %s\n", got, 4*time.Second)
+	}
+
+	var buf bytes.Buffer
+	write(&buf, "stopwatch", s, false)
+	if !strings.Contains(buf.String(), "lap 1: 1s") || !strings.Contains(buf.String(), "elapsed: 4s") {
+		t.Errorf("Replay: unexpected output:\n%s\n", buf.String())
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !illumos && !linux && !netbsd && !openbsd && !solaris
+// +build !aix,!darwin,!dragonfly,!freebsd,!illumos,!linux,!netbsd,!openbsd,!solaris
+
+package main
+
+// notifyLap is a no-op, there is no SIGUSR1 on this platform.
+func notifyLap(fn func()) (stop func()) {
+	return func() {}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/fatih/stopwatch"
+)
+
+// Kinds of the recorded state changes.
+const (
+	stateStart = "start"
+	stateLap   = "lap"
+	stateStop  = "stop"
+)
+
+// state is the content of the state file. It records every state change,
+// which are replayed to restore the stopwatch.
+type state struct {
+	Events []stateEvent `json:"events"`
+
+	clock *replayClock
+}
+
+type stateEvent struct {
+	Kind string    `json:"kind"`
+	Time time.Time `json:"time"`
+}
+
+// replayClock returns the time of the event that is replayed and the system
+// time otherwise.
+type replayClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *replayClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.t.IsZero() {
+		return time.Now()
+	}
+	return c.t
+}
+
+func (c *replayClock) set(t time.Time) {
+	c.mu.Lock()
+	c.t = t
+	c.mu.Unlock()
+}
+
+// loadState reads the state file at path. A missing file is a reseted
+// stopwatch.
+func loadState(path string) (*state, error) {
+	st := &state{clock: &replayClock{}}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return st, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+func (st *state) save(path string) error {
+	data, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// stopwatch replays the recorded events into a new stopwatch.
+func (st *state) stopwatch() *stopwatch.Stopwatch {
+	s := stopwatch.New(stopwatch.WithClock(st.clock))
+	for _, e := range st.Events {
+		st.clock.set(e.Time)
+		do(s, e.Kind)
+	}
+	st.clock.set(time.Time{})
+	return s
+}
+
+// apply applies the given kind of event to s and records it. It returns
+// false if the event has no effect in the current state.
+func (st *state) apply(s *stopwatch.Stopwatch, kind string) bool {
+	running := !s.IsReseted() && !s.IsStopped()
+	if (kind == stateStart && running) || (kind != stateStart && !running) {
+		return false
+	}
+
+	now := time.Now()
+	st.clock.set(now)
+	do(s, kind)
+	st.clock.set(time.Time{})
+
+	st.Events = append(st.Events, stateEvent{Kind: kind, Time: now})
+	return true
+}
+
+func do(s *stopwatch.Stopwatch, kind string) {
+	switch kind {
+	case stateStart:
+		s.Start(0)
+	case stateLap:
+		s.Lap()
+	case stateStop:
+		s.Stop()
+	}
+}
@@ -0,0 +1,59 @@
+package stopwatch
+
+import (
+	"io"
+	"os"
+	"time"
+)
+
+// ANSI escape sequences used by WithColor.
+const (
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+	colorRed    = "\x1b[31m"
+	colorReset  = "\x1b[0m"
+)
+
+// colorThresholds are the thresholds set with WithColor.
+type colorThresholds struct {
+	warn, crit time.Duration
+}
+
+// isTerminal reports whether w is a terminal that shows colors. It is a
+// variable to be replaced in tests.
+var isTerminal = func(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// WithColor highlights durations in Print, Log, Display and the plain text
+// report: green below warn, yellow below crit and red above. Colors are only
+// used if the output is a terminal and the NO_COLOR environment variable is
+// not set, so CI logs redirected to files stay plain.
+func WithColor(warn, crit time.Duration) Option {
+	return func(s *Stopwatch) { s.colors = &colorThresholds{warn: warn, crit: crit} }
+}
+
+// colorFormat returns a function formatting durations for w, colored if w
+// is a terminal, see WithColor.
+func (s *Stopwatch) colorFormat(w io.Writer) func(time.Duration) string {
+	if s.colors == nil || !isTerminal(w) {
+		return s.formatDuration
+	}
+
+	return func(d time.Duration) string {
+		color := colorGreen
+		switch {
+		case d >= s.colors.crit:
+			color = colorRed
+		case d >= s.colors.warn:
+			color = colorYellow
+		}
+		return color + s.formatDuration(d) + colorReset
+	}
+}
@@ -0,0 +1,50 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_WithColor(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c), WithColor(time.Second, 2*time.Second))
+
+	c.Advance(500 * time.Millisecond)
+	sw.Lap()
+	c.Advance(1500 * time.Millisecond)
+	sw.Lap()
+	c.Advance(3 * time.Second)
+	sw.Lap()
+
+	var buf bytes.Buffer
+	if err := sw.Report(&buf, ReportText); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("Report: got:\n%q\nexpected no colors for a writer that is not a terminal\n", buf.String())
+	}
+
+	defer func(f func(io.Writer) bool) { isTerminal = f }(isTerminal)
+	isTerminal = func(io.Writer) bool { return true }
+
+	buf.Reset()
+	if err := sw.Report(&buf, ReportText); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	for _, s := range []string{colorGreen + "500ms", colorYellow + "1.5s", colorRed + "3s" + colorReset} {
+		if !strings.Contains(buf.String(), s) {
+			t.Errorf("Report: got:\n%q\nexpected: %q\n", buf.String(), s)
+		}
+	}
+
+	if f := Start(0).colorFormat(os.Stdout); f(time.Second) != "1s" {
+		t.Error("colorFormat: expected no colors without WithColor")
+	}
+}
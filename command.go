@@ -0,0 +1,108 @@
+package stopwatch
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+)
+
+// Command runs cmd like cmd.Run and returns a stopped stopwatch with the
+// phases of the command as sections:
+//
+//	start  until the process is started
+//	run    until the process exits
+//	drain  until the output of the process is copied to cmd.Stdout and
+//	       cmd.Stderr, if they are not files
+//
+// The stopwatch is tagged with the base name of the command. The error is the
+// one of cmd.Run, or the first error copying the output.
+// Example : s, err := stopwatch.Command(exec.Command("make", "build"))
+func Command(cmd *exec.Cmd, opts ...Option) (*Stopwatch, error) {
+	s := Start(0, opts...)
+	defer s.Stop()
+	s.SetTag("command", filepath.Base(cmd.Path))
+
+	stdout, stderr := cmd.Stdout, cmd.Stderr
+	defer func() { cmd.Stdout, cmd.Stderr = stdout, stderr }()
+
+	// Output that is not written to a file is copied by the stopwatch, not by
+	// cmd.Wait, so that the drain is timed on its own.
+	var (
+		drain   sync.WaitGroup
+		writers []*os.File
+		copyErr error
+		mu      sync.Mutex
+	)
+	pipe := func(w io.Writer) (io.Writer, error) {
+		if _, ok := w.(*os.File); ok || w == nil {
+			return w, nil
+		}
+
+		pr, pw, err := os.Pipe()
+		if err != nil {
+			return nil, err
+		}
+		writers = append(writers, pw)
+
+		drain.Add(1)
+		go func() {
+			defer drain.Done()
+			_, err := io.Copy(w, pr)
+			pr.Close()
+
+			mu.Lock()
+			if copyErr == nil {
+				copyErr = err
+			}
+			mu.Unlock()
+		}()
+		return pw, nil
+	}
+	closeWriters := func() {
+		for _, pw := range writers {
+			pw.Close()
+		}
+		writers = nil
+	}
+
+	endStart := s.Section("start")
+	var err error
+	if cmd.Stdout, err = pipe(stdout); err == nil {
+		if sameWriter(stdout, stderr) {
+			cmd.Stderr = cmd.Stdout
+		} else {
+			cmd.Stderr, err = pipe(stderr)
+		}
+	}
+	if err == nil {
+		err = cmd.Start()
+	}
+	closeWriters()
+	endStart()
+	if err != nil {
+		drain.Wait()
+		return s, err
+	}
+
+	endRun := s.Section("run")
+	err = cmd.Wait()
+	endRun()
+
+	endDrain := s.Section("drain")
+	drain.Wait()
+	endDrain()
+
+	if err == nil {
+		err = copyErr
+	}
+	return s, err
+}
+
+// sameWriter reports whether a and b are the same writer. Writers that are
+// not comparable are never the same.
+func sameWriter(a, b io.Writer) (same bool) {
+	defer func() { recover() }()
+	return a != nil && a == b
+}
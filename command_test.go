@@ -0,0 +1,60 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestCommand(t *testing.T) {
+	var out bytes.Buffer
+	cmd := exec.Command(os.Args[0], "-test.run=TestCommandHelper")
+	cmd.Env = append(os.Environ(), "STOPWATCH_COMMAND_HELPER=1")
+	cmd.Stdout, cmd.Stderr = &out, &out
+
+	sw, err := Command(cmd)
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	if !strings.Contains(out.String(), "hello") {
+		t.Errorf("Command: got output: %q expected: hello\n", out.String())
+	}
+	if cmd.Stdout != &out {
+		t.Error("Command: cmd.Stdout should be restored")
+	}
+	if !sw.IsStopped() {
+		t.Error("Command: the stopwatch should be stopped")
+	}
+
+	var names []string
+	for _, c := range sw.Sections() {
+		names = append(names, c.Name)
+	}
+	if got := strings.Join(names, " "); got != "start run drain" {
+		t.Errorf("Command: got sections: %s expected: start run drain\n", got)
+	}
+
+	sw, err = Command(exec.Command("stopwatch-no-such-command"))
+	if err == nil {
+		t.Error("Command: expected an error for an unknown command")
+	}
+	if len(sw.Sections()) != 1 {
+		t.Errorf("Command: got: %d sections expected: 1\n", len(sw.Sections()))
+	}
+}
+
+// TestCommandHelper is the process run by TestCommand.
+func TestCommandHelper(t *testing.T) {
+	if os.Getenv("STOPWATCH_COMMAND_HELPER") != "1" {
+		return
+	}
+	fmt.Println("hello")
+	os.Exit(0)
+}
@@ -0,0 +1,138 @@
+package stopwatch
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Exceeds reports whether the elapsed time of the stopwatch is longer than d.
+// Example : if s.Exceeds(budget) { ... }
+func (s *Stopwatch) Exceeds(d time.Duration) bool {
+	return s.ElapsedTime() > d
+}
+
+// Compare compares the elapsed times of a and b. It returns -1 if a is
+// shorter than b, 1 if it is longer and 0 if both are equal.
+func Compare(a, b *Stopwatch) int {
+	da, db := a.ElapsedTime(), b.ElapsedTime()
+	switch {
+	case da < db:
+		return -1
+	case da > db:
+		return 1
+	}
+	return 0
+}
+
+// Delta returns the elapsed time of b minus the elapsed time of a, such as
+// the change between a run before and after an optimization. It is negative
+// if b is shorter.
+// Example : stopwatch.Delta(before, after)
+func Delta(a, b *Stopwatch) time.Duration {
+	return b.ElapsedTime() - a.ElapsedTime()
+}
+
+// compareRow is a line of CompareReport. A missing value is -1.
+type compareRow struct {
+	name          string
+	before, after time.Duration
+}
+
+// CompareReport writes a table to w comparing the total elapsed time, the
+// sections and the laps of two runs, such as before and after an
+// optimization. Sections are lined up by their path and laps by their label,
+// see LapWithLabel. Repeated sections and laps with the same label are summed
+// up. Each line has the delta and the percentage change of the after run.
+// Example output:
+//
+//	name    before  after  delta   change
+//	total   2s      1.5s   -500ms  -25.0%
+//	parse   1s      500ms  -500ms  -50.0%
+//	lap db  300ms   400ms  +100ms  +33.3%
+func CompareReport(before, after *Stopwatch, w io.Writer) error {
+	rows := []compareRow{{name: "total", before: before.ElapsedTime(), after: after.ElapsedTime()}}
+	index := make(map[string]int)
+	add := func(name string, d time.Duration, isAfter bool) {
+		i, ok := index[name]
+		if !ok {
+			i = len(rows)
+			index[name] = i
+			rows = append(rows, compareRow{name: name, before: -1, after: -1})
+		}
+
+		r := &rows[i]
+		v := &r.before
+		if isAfter {
+			v = &r.after
+		}
+		if *v < 0 {
+			*v = 0
+		}
+		*v += d
+	}
+
+	for i, s := range []*Stopwatch{before, after} {
+		for _, c := range s.report(0).Sections {
+			add(c.Name, c.Elapsed, i == 1)
+		}
+	}
+
+	for i, s := range []*Stopwatch{before, after} {
+		stats := s.AggregateByLabel()
+		labels := make([]string, 0, len(stats))
+		for label := range stats {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+
+		for _, label := range labels {
+			add("lap "+label, stats[label].Total, i == 1)
+		}
+	}
+
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "name\tbefore\tafter\tdelta\tchange")
+	for _, r := range rows {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", r.name,
+			formatCompare(after, r.before), formatCompare(after, r.after),
+			formatDelta(after, r.before, r.after), formatChange(r.before, r.after))
+	}
+	tw.Flush()
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatCompare formats a value of CompareReport with the duration format of
+// s, a missing value is "-".
+func formatCompare(s *Stopwatch, d time.Duration) string {
+	if d < 0 {
+		return "-"
+	}
+	return s.formatDuration(d)
+}
+
+// formatDelta formats the signed difference of two values of CompareReport.
+func formatDelta(s *Stopwatch, before, after time.Duration) string {
+	switch {
+	case before < 0 || after < 0:
+		return "-"
+	case after < before:
+		return "-" + s.formatDuration(before-after)
+	}
+	return "+" + s.formatDuration(after-before)
+}
+
+// formatChange formats the percentage change of two values of
+// CompareReport. It is "-" if there is nothing to compare to.
+func formatChange(before, after time.Duration) string {
+	if before <= 0 || after < 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f%%", float64(after-before)/float64(before)*100)
+}
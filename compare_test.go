@@ -0,0 +1,79 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_Compare(t *testing.T) {
+	c := newClock()
+	before := Start(0, WithClock(c))
+	after := Start(0, WithClock(c))
+
+	c.Advance(time.Second)
+	after.Stop()
+	c.Advance(time.Second)
+	before.Stop()
+
+	if !before.Exceeds(time.Second) || before.Exceeds(2*time.Second) {
+		t.Errorf("Exceeds: got wrong result for elapsed %s\n", before.ElapsedTime())
+	}
+
+	if got := Compare(before, after); got != 1 {
+		t.Errorf("Compare: got: %d expected: 1\n", got)
+	}
+	if got := Compare(after, before); got != -1 {
+		t.Errorf("Compare: got: %d expected: -1\n", got)
+	}
+	if got := Compare(after, after); got != 0 {
+		t.Errorf("Compare: got: %d expected: 0\n", got)
+	}
+
+	if got := Delta(before, after); got != -time.Second {
+		t.Errorf("Delta: got: %s expected: -1s\n", got)
+	}
+}
+
+func TestCompareReport(t *testing.T) {
+	c := newClock()
+	before := Start(0, WithClock(c))
+	end := before.Section("parse")
+	c.Advance(time.Second)
+	end()
+	before.LapWithLabel("db")
+	c.Advance(time.Second)
+	before.LapWithLabel("db")
+	before.Stop()
+
+	after := Start(0, WithClock(c))
+	end = after.Section("parse")
+	c.Advance(500 * time.Millisecond)
+	end()
+	after.LapWithLabel("db")
+	end = after.Section("render")
+	c.Advance(time.Second)
+	end()
+	after.Stop()
+
+	var buf bytes.Buffer
+	if err := CompareReport(before, after, &buf); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	expected := strings.Join([]string{
+		"name    before  after  delta   change",
+		"total   2s      1.5s   -500ms  -25.0%",
+		"parse   1s      500ms  -500ms  -50.0%",
+		"render  -       1s     -       -",
+		"lap db  2s      500ms  -1.5s   -75.0%",
+		"",
+	}, "\n")
+	if got := buf.String(); got != expected {
+		t.Errorf("CompareReport: got:\n%s\nexpected:\n%s\n", got, expected)
+	}
+}
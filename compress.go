@@ -0,0 +1,77 @@
+package stopwatch
+
+import (
+	"compress/gzip"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Compressor compresses files written by WriteReportFile, WriteJSONLFile and
+// Archive. Files are compressed if their name ends with the extension the
+// compressor is registered for, such as "report.json.gz".
+type Compressor struct {
+	NewWriter func(w io.Writer) (io.WriteCloser, error)
+
+	// NewReader is used to read archives. The reader must continue with the
+	// next stream at the end of a stream, as archives are appended to with a
+	// new stream per session. gzip.Reader does so by default.
+	NewReader func(r io.Reader) (io.ReadCloser, error)
+}
+
+var compressors = struct {
+	sync.Mutex
+	byExt map[string]Compressor
+}{
+	byExt: map[string]Compressor{
+		".gz": {
+			NewWriter: func(w io.Writer) (io.WriteCloser, error) { return gzip.NewWriter(w), nil },
+			NewReader: func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) },
+		},
+	},
+}
+
+// RegisterCompressor registers c for files with the given extension, such as
+// ".lz4". gzip is registered for ".gz" by default, zstd for ".zst" by
+// importing the zstdstopwatch package.
+func RegisterCompressor(ext string, c Compressor) {
+	compressors.Lock()
+	compressors.byExt[strings.ToLower(ext)] = c
+	compressors.Unlock()
+}
+
+// compressorFor returns the compressor for path and the path without the
+// compression extension. It returns nil if path is not compressed.
+func compressorFor(path string) (*Compressor, string) {
+	ext := strings.ToLower(filepath.Ext(path))
+
+	compressors.Lock()
+	c, ok := compressors.byExt[ext]
+	compressors.Unlock()
+
+	if !ok {
+		return nil, path
+	}
+	return &c, path[:len(path)-len(ext)]
+}
+
+// compressTo calls fn with a writer compressing to w with c. A nil c calls fn
+// with w.
+func compressTo(w io.Writer, c *Compressor, fn func(w io.Writer) error) error {
+	if c == nil {
+		return fn(w)
+	}
+
+	cw, err := c.NewWriter(w)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(cw); err != nil {
+		cw.Close()
+		return err
+	}
+
+	return cw.Close()
+}
@@ -0,0 +1,111 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_WriteReportFileCompressed(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	c.Advance(time.Second)
+	sw.Lap()
+
+	path := filepath.Join(t.TempDir(), "report.json.gz")
+	if err := sw.WriteReportFile(path); err != nil {
+		t.Fatalf("WriteReportFile: error: %s\n", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("WriteReportFile: error: %s\n", err)
+	}
+	defer f.Close()
+
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("WriteReportFile: the report is not gzipped: %s\n", err)
+	}
+
+	var v map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&v); err != nil {
+		t.Fatalf("WriteReportFile: the report is not JSON: %s\n", err)
+	}
+
+	if v["elapsed"] == nil {
+		t.Errorf("WriteReportFile: unexpected report %v\n", v)
+	}
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+func TestRegisterCompressor(t *testing.T) {
+	var used bool
+	RegisterCompressor(".Upper", Compressor{
+		NewWriter: func(w io.Writer) (io.WriteCloser, error) {
+			used = true
+			return nopWriteCloser{w}, nil
+		},
+		NewReader: func(r io.Reader) (io.ReadCloser, error) { return io.NopCloser(r), nil },
+	})
+	defer func() {
+		compressors.Lock()
+		delete(compressors.byExt, ".upper")
+		compressors.Unlock()
+	}()
+
+	path := filepath.Join(t.TempDir(), "report.md.upper")
+	if err := Start(0).WriteReportFile(path); err != nil {
+		t.Fatalf("RegisterCompressor: error: %s\n", err)
+	}
+
+	if !used {
+		t.Errorf("RegisterCompressor: the compressor was not used\n")
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "Elapsed") {
+		t.Errorf("RegisterCompressor: expected a markdown report, got:\n%s\n", data)
+	}
+}
+
+func TestArchive_Compressed(t *testing.T) {
+	c := newClock()
+	path := filepath.Join(t.TempDir(), "sessions.jsonl.gz")
+	a := NewArchive(path, Retention{MaxSessions: 2}, c)
+
+	for i := 0; i < 3; i++ {
+		sw := Start(0, WithClock(c))
+		c.Advance(time.Duration(i+1) * time.Second)
+		sw.Stop()
+		if err := a.Add(sw); err != nil {
+			t.Fatalf("Add: error: %s\n", err)
+		}
+	}
+
+	sessions, err := a.Sessions()
+	if err != nil {
+		t.Fatalf("Sessions: error: %s\n", err)
+	}
+
+	if len(sessions) != 2 || sessions[0].Elapsed != 2*time.Second || sessions[1].Elapsed != 3*time.Second {
+		t.Errorf("Sessions: unexpected sessions %+v\n", sessions)
+	}
+
+	f, _ := os.Open(path)
+	defer f.Close()
+	if _, err := gzip.NewReader(f); err != nil {
+		t.Errorf("Archive: the archive is not gzipped: %s\n", err)
+	}
+}
@@ -0,0 +1,87 @@
+package stopwatch
+
+import (
+	"context"
+	"sync"
+)
+
+// contextKey is the key under which a Stopwatch is stored in a context.
+type contextKey struct{}
+
+// NewContext returns a new context that carries s.
+func NewContext(ctx context.Context, s *Stopwatch) context.Context {
+	return context.WithValue(ctx, contextKey{}, s)
+}
+
+// FromContext returns the Stopwatch stored in ctx or nil if there is none.
+func FromContext(ctx context.Context) *Stopwatch {
+	s, _ := ctx.Value(contextKey{}).(*Stopwatch)
+	return s
+}
+
+// ContextAction defines what a stopwatch bound to a context does once the
+// context is done, see BindContext.
+type ContextAction int
+
+const (
+	// ContextStop stops the stopwatch.
+	ContextStop ContextAction = iota
+
+	// ContextLap takes a lap.
+	ContextLap
+)
+
+// StartWithContext starts a new stopwatch that is stopped once ctx is done.
+// The deadline of ctx, if any, is the deadline of the stopwatch, see
+// Remaining. The returned context carries the stopwatch, see FromContext.
+func StartWithContext(ctx context.Context, opts ...Option) (context.Context, *Stopwatch) {
+	s := Start(0, opts...)
+	s.SetDeadlineFromContext(ctx)
+	s.BindContext(ctx, ContextStop)
+	return NewContext(ctx, s), s
+}
+
+// BindContext stops the stopwatch or takes a lap, depending on action, once
+// ctx is done. The returned function unbinds the stopwatch from ctx, calling
+// it after ctx is done has no effect.
+func (s *Stopwatch) BindContext(ctx context.Context, action ContextAction) (unbind func()) {
+	done := ctx.Done()
+	if done == nil { // never canceled
+		return func() {}
+	}
+
+	unbound := make(chan struct{})
+	var once sync.Once
+	unbind = func() { once.Do(func() { close(unbound) }) }
+
+	exited := make(chan struct{})
+	untrack := s.addTask(func() {
+		unbind()
+		<-exited
+	})
+
+	go func() {
+		defer close(exited)
+		defer untrack()
+
+		select {
+		case <-done:
+			// select picks randomly if both are ready, unbinding first wins
+			select {
+			case <-unbound:
+				return
+			default:
+			}
+
+			switch action {
+			case ContextStop:
+				s.Stop()
+			case ContextLap:
+				s.Lap()
+			}
+		case <-unbound:
+		}
+	}()
+
+	return unbind
+}
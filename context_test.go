@@ -0,0 +1,89 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestContext(t *testing.T) {
+	if FromContext(context.Background()) != nil {
+		t.Error("FromContext: an empty context should not carry a stopwatch")
+	}
+
+	sw := New()
+	if FromContext(NewContext(context.Background(), sw)) != sw {
+		t.Error("FromContext: should return the stopwatch stored with NewContext")
+	}
+}
+
+func TestStartWithContext(t *testing.T) {
+	c := newClock()
+	parent, cancel := context.WithCancel(context.Background())
+	ctx, sw := StartWithContext(parent, WithClock(c))
+
+	if FromContext(ctx) != sw {
+		t.Error("StartWithContext: the context should carry the stopwatch")
+	}
+
+	stopped := make(chan struct{})
+	sw.OnEvent(func(e Event) {
+		if e.Kind == EventStop {
+			close(stopped)
+		}
+	})
+
+	c.Advance(time.Second)
+	cancel()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("StartWithContext: the stopwatch should stop once the context is done")
+	}
+
+	if got := sw.ElapsedTime(); got != time.Second {
+		t.Errorf("StartWithContext: got: %s expected: %s\n", got, time.Second)
+	}
+}
+
+func TestStopwatch_BindContext(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+
+	lapped := make(chan struct{}, 1)
+	sw.OnEvent(func(e Event) {
+		if e.Kind == EventLap {
+			lapped <- struct{}{}
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sw.BindContext(ctx, ContextLap)
+	c.Advance(time.Second)
+	cancel()
+
+	select {
+	case <-lapped:
+	case <-time.After(time.Second):
+		t.Fatal("BindContext: a lap should be taken once the context is done")
+	}
+
+	if sw.IsStopped() {
+		t.Error("BindContext: ContextLap should not stop the stopwatch")
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	unbind := sw.BindContext(ctx, ContextStop)
+	unbind()
+	unbind()
+	cancel()
+
+	time.Sleep(10 * time.Millisecond)
+	if sw.IsStopped() {
+		t.Error("BindContext: an unbound stopwatch should not be stopped")
+	}
+}
@@ -0,0 +1,62 @@
+package stopwatch
+
+import "time"
+
+// processCPUTime returns the user and system CPU time consumed by the process
+// so far. The boolean is false if it is not available on the platform. It is
+// a variable to be replaced in tests.
+var processCPUTime = cpuTime
+
+// WithCPUTime makes the stopwatch measure the CPU time of the process, user
+// and system, in addition to the wall time, see CPUElapsed. Wall time alone is
+// misleading for CPU bound work. CPU time is measured for the whole process,
+// so it includes the work of all goroutines.
+func WithCPUTime() Option {
+	return func(s *Stopwatch) { s.cpu = true }
+}
+
+// CPUElapsed returns the CPU time the process consumed while the stopwatch was
+// running. It returns zero if the stopwatch wasn't created with WithCPUTime or
+// if CPU time is not available on the platform.
+func (s *Stopwatch) CPUElapsed() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.cpu || s.isReseted() {
+		return 0
+	}
+
+	elapsed := s.cpuTotal
+	if s.isRunning() {
+		if now, ok := processCPUTime(); ok {
+			elapsed += now - s.cpuMark
+		}
+	}
+	return elapsed
+}
+
+// resetCPU clears the CPU time and starts measuring it from now on. The lock
+// must be held.
+func (s *Stopwatch) resetCPU() {
+	s.cpuTotal = 0
+	s.resumeCPU()
+}
+
+// resumeCPU starts measuring the CPU time from now on. The lock must be held.
+func (s *Stopwatch) resumeCPU() {
+	if s.cpu {
+		s.cpuMark, _ = processCPUTime()
+	}
+}
+
+// pauseCPU adds the CPU time since the latest resume to the total. The lock
+// must be held.
+func (s *Stopwatch) pauseCPU() {
+	if !s.cpu {
+		return
+	}
+
+	if now, ok := processCPUTime(); ok {
+		s.cpuTotal += now - s.cpuMark
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris && !windows
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris,!windows
+
+package stopwatch
+
+import "time"
+
+// cpuTime is not available on this platform.
+func cpuTime() (time.Duration, bool) {
+	return 0, false
+}
@@ -0,0 +1,49 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStopwatch_CPUElapsed(t *testing.T) {
+	var cpu time.Duration
+	defer func(f func() (time.Duration, bool)) { processCPUTime = f }(processCPUTime)
+	processCPUTime = func() (time.Duration, bool) { return cpu, true }
+
+	sw := Start(0, WithCPUTime())
+	cpu += time.Second
+	if d := sw.CPUElapsed(); d != time.Second {
+		t.Errorf("CPUElapsed: got: %s expected: 1s\n", d)
+	}
+
+	sw.Stop()
+	cpu += time.Minute // excluded while stopped
+	sw.Start(0)
+	cpu += time.Second
+
+	if d := sw.CPUElapsed(); d != 2*time.Second {
+		t.Errorf("CPUElapsed: got: %s expected: 2s\n", d)
+	}
+
+	sw.Reset()
+	if d := sw.CPUElapsed(); d != 0 {
+		t.Errorf("CPUElapsed: got: %s expected: 0 after a reset\n", d)
+	}
+
+	if d := Start(0).CPUElapsed(); d != 0 {
+		t.Errorf("CPUElapsed: got: %s expected: 0 without WithCPUTime\n", d)
+	}
+}
+
+func TestCPUTime(t *testing.T) {
+	d, ok := cpuTime()
+	if !ok {
+		t.Skip("CPU time is not available on this platform")
+	}
+	if d <= 0 {
+		t.Errorf("cpuTime: got: %s expected a positive duration\n", d)
+	}
+}
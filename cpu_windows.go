@@ -0,0 +1,27 @@
+package stopwatch
+
+import (
+	"syscall"
+	"time"
+)
+
+// cpuTime returns the user and kernel CPU time of the process via
+// GetProcessTimes.
+func cpuTime() (time.Duration, bool) {
+	h, err := syscall.GetCurrentProcess()
+	if err != nil {
+		return 0, false
+	}
+
+	var creation, exit, kernel, user syscall.Filetime
+	if err := syscall.GetProcessTimes(h, &creation, &exit, &kernel, &user); err != nil {
+		return 0, false
+	}
+	return filetimeDuration(kernel) + filetimeDuration(user), true
+}
+
+// filetimeDuration converts a Filetime holding a duration in 100-nanosecond
+// intervals.
+func filetimeDuration(ft syscall.Filetime) time.Duration {
+	return time.Duration(int64(ft.HighDateTime)<<32|int64(ft.LowDateTime)) * 100
+}
@@ -0,0 +1,86 @@
+package stopwatch
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// SetDeadline sets the time by which the measured work should be done, see
+// Remaining and Overrun. A session passing it delivers an EventDeadline with
+// its next lap, end of a section or stop. A zero time removes the deadline. The deadline is
+// kept across resets.
+// Example : s.SetDeadline(time.Now().Add(200 * time.Millisecond))
+func (s *Stopwatch) SetDeadline(t time.Time) {
+	s.mu.Lock()
+	s.deadline = t
+	s.mu.Unlock()
+}
+
+// SetDeadlineFromContext sets the deadline of ctx as the deadline of the
+// stopwatch, see SetDeadline. It reports whether ctx has a deadline, the
+// deadline of the stopwatch is left untouched otherwise.
+func (s *Stopwatch) SetDeadlineFromContext(ctx context.Context) bool {
+	t, ok := ctx.Deadline()
+	if ok {
+		s.SetDeadline(t)
+	}
+	return ok
+}
+
+// Deadline returns the deadline and whether one is set.
+func (s *Stopwatch) Deadline() (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deadline, !s.deadline.IsZero()
+}
+
+// Remaining returns the time left until the deadline, or zero once it passed.
+// Without a deadline there is all the time, it returns the maximum duration.
+// Example : if s.Remaining() < 50*time.Millisecond { return cached }
+func (s *Stopwatch) Remaining() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.deadline.IsZero() {
+		return time.Duration(math.MaxInt64)
+	}
+	if d := s.deadline.Sub(s.now()); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// checkDeadline appends an EventDeadline to events the first time the
+// session is seen past its deadline, by a lap, the end of a section or a
+// stop. The lock must be held.
+func (s *Stopwatch) checkDeadline(events []Event) []Event {
+	if s.deadline.IsZero() || s.missed || !s.isRunning() {
+		return events
+	}
+
+	overrun := s.since(s.deadline)
+	if overrun <= 0 {
+		return events
+	}
+
+	s.missed = true
+	e := s.breach(EventDeadline, s.section)
+	e.Duration = overrun
+	return append(events, e)
+}
+
+// Overrun returns how long the deadline has passed, or zero if it has not
+// passed or there is none.
+func (s *Stopwatch) Overrun() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.deadline.IsZero() {
+		return 0
+	}
+	if d := s.since(s.deadline); d > 0 {
+		return d
+	}
+	return 0
+}
@@ -0,0 +1,47 @@
+package stopwatch
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_Deadline(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	if sw.Remaining() != time.Duration(math.MaxInt64) || sw.Overrun() != 0 {
+		t.Errorf("Remaining: got: %s expected all the time without a deadline\n", sw.Remaining())
+	}
+
+	sw.SetDeadline(c.Now().Add(time.Second))
+	c.Advance(400 * time.Millisecond)
+	if got := sw.Remaining(); got != 600*time.Millisecond {
+		t.Errorf("Remaining: got: %s expected: 600ms\n", got)
+	}
+
+	c.Advance(time.Second)
+	if got := sw.Remaining(); got != 0 {
+		t.Errorf("Remaining: got: %s expected: 0s\n", got)
+	}
+	if got := sw.Overrun(); got != 400*time.Millisecond {
+		t.Errorf("Overrun: got: %s expected: 400ms\n", got)
+	}
+
+	deadline := c.Now().Add(time.Minute)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	if !sw.SetDeadlineFromContext(ctx) {
+		t.Error("SetDeadlineFromContext: the context has a deadline")
+	}
+	if got, ok := sw.Deadline(); !ok || !got.Equal(deadline) {
+		t.Errorf("Deadline: got: %s expected: %s\n", got, deadline)
+	}
+
+	if sw.SetDeadlineFromContext(context.Background()) {
+		t.Error("SetDeadlineFromContext: the context has no deadline")
+	}
+	if _, ok := sw.Deadline(); !ok {
+		t.Error("SetDeadlineFromContext: the deadline should be kept")
+	}
+}
@@ -0,0 +1,100 @@
+package stopwatch
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// displayLaps is the number of latest laps shown by Display.
+const displayLaps = 10
+
+// Display repaints the elapsed time and a table of the latest laps to w every
+// interval, using ANSI escape sequences to move the cursor back up. w is
+// expected to be a terminal. The display ends with a final repaint once the
+// stopwatch is stopped or reseted. The returned function ends the display as
+// well and waits until it is done, it can be called more than once. The event
+// hook ending the display is removed once it is done.
+// Example : defer s.Display(os.Stderr, 100*time.Millisecond)()
+func (s *Stopwatch) Display(w io.Writer, interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	var once sync.Once
+	end := func() { once.Do(func() { close(done) }) }
+
+	remove := s.OnEvent(func(e Event) {
+		if e.Kind == EventStop || e.Kind == EventReset {
+			end()
+		}
+	})
+
+	finished := make(chan struct{})
+	stop = func() {
+		end()
+		<-finished
+	}
+	untrack := s.addTask(stop)
+
+	go func() {
+		defer close(finished)
+		defer untrack()
+		defer remove()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		lines := 0
+		for {
+			var running bool
+			lines, running = s.render(w, lines)
+			if !running {
+				return
+			}
+
+			select {
+			case <-done:
+				s.render(w, lines)
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return stop
+}
+
+// render paints the current state over the previous lines painted and
+// returns the number of painted lines and whether the stopwatch is running.
+func (s *Stopwatch) render(w io.Writer, prev int) (int, bool) {
+	s.mu.Lock()
+	elapsed, running := s.elapsed(), s.isRunning()
+	laps := s.lapRecords()
+	first := s.lapStats.Count - len(laps)
+	if len(laps) > displayLaps {
+		first += len(laps) - displayLaps
+		laps = laps[len(laps)-displayLaps:]
+	}
+	s.mu.Unlock()
+
+	format := s.colorFormat(w)
+	lines := []string{"elapsed: " + format(elapsed)}
+	if first > 0 {
+		lines = append(lines, fmt.Sprintf("... %d earlier laps", first))
+	}
+	for i, lap := range laps {
+		lines = append(lines, fmt.Sprintf("lap %3d  %s", first+i+1, format(lap.Duration)))
+	}
+
+	var b strings.Builder
+	if prev > 0 {
+		fmt.Fprintf(&b, "\x1b[%dA", prev) // cursor up
+	}
+	for _, line := range lines {
+		b.WriteString("\r\x1b[2K" + line + "\n") // clear line
+	}
+	b.WriteString("\x1b[J") // clear the rest of the screen
+
+	io.WriteString(w, b.String())
+	return len(lines), running
+}
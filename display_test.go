@@ -0,0 +1,74 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_Display(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+
+	var buf syncBuffer
+	stop := sw.Display(&buf, time.Millisecond)
+
+	c.Advance(time.Second)
+	sw.Lap()
+	c.Advance(time.Second)
+	sw.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("Display: should end once the stopwatch is stopped\n")
+	}
+
+	out := buf.String()
+	frames := strings.Split(out, "\x1b[J")
+	last := frames[len(frames)-2]
+	if !strings.Contains(last, "elapsed: 2s") || !strings.Contains(last, "lap   1  1s") {
+		t.Errorf("Display: unexpected final frame %q\n", last)
+	}
+
+	if len(frames) > 2 && !strings.Contains(out, "\x1b[2A") {
+		t.Errorf("Display: repaints should move the cursor up, got %q\n", out)
+	}
+	if n := hookCount(sw); n != 0 {
+		t.Errorf("Display: got: %d hooks after stop expected: 0\n", n)
+	}
+
+	sw.Start(0)
+	sw.Display(io.Discard, time.Millisecond)()
+	if n := hookCount(sw); n != 0 {
+		t.Errorf("Display: got: %d hooks after stop expected: 0\n", n)
+	}
+}
+
+func TestStopwatch_DisplayLaps(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	for i := 0; i < displayLaps+5; i++ {
+		c.Advance(time.Second)
+		sw.Lap()
+	}
+	sw.Stop()
+
+	var buf syncBuffer
+	sw.Display(&buf, time.Hour)() // renders once, the stopwatch is stopped
+
+	out := buf.String()
+	if !strings.Contains(out, "... 5 earlier laps") || !strings.Contains(out, "lap  15") || strings.Contains(out, "lap   5 ") {
+		t.Errorf("Display: unexpected laps:\n%s\n", out)
+	}
+}
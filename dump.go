@@ -0,0 +1,70 @@
+package stopwatch
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// Dump writes the state of all registered stopwatches to w as plain text
+// reports, sorted by name. Each report starts with a header line carrying
+// the name and the state of the stopwatch.
+// Example output:
+//
+//	=== build (running)
+//	Elapsed: 1.5s
+//	...
+func Dump(w io.Writer) error {
+	var b bytes.Buffer
+	for _, name := range registered() {
+		s := Lookup(name)
+		if s == nil {
+			continue
+		}
+
+		s.mu.Lock()
+		state := s.state()
+		s.mu.Unlock()
+
+		b.WriteString("=== " + name + " (" + state + ")\n")
+		if err := s.Report(&b, ReportText); err != nil {
+			return err
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// DumpOnSignal writes a Dump to w every time the process receives sig, such
+// as syscall.SIGUSR1, to inspect a stuck service without a debugger. The
+// returned function stops listening for the signal, it can be called more
+// than once.
+// Example : defer stopwatch.DumpOnSignal(syscall.SIGUSR1, os.Stderr)()
+func DumpOnSignal(sig os.Signal, w io.Writer) (stop func()) {
+	c := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(c, sig)
+
+	go func() {
+		for {
+			select {
+			case <-c:
+				Dump(w)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(c)
+			close(done)
+		})
+	}
+}
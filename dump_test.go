@@ -0,0 +1,49 @@
+//go:build (aix || darwin || dragonfly || freebsd || illumos || linux || netbsd || openbsd || solaris) && !stopwatch_off
+// +build aix darwin dragonfly freebsd illumos linux netbsd openbsd solaris
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestDump(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	c.Advance(time.Second)
+	sw.Lap()
+	Register("dump", sw)
+	defer Unregister("dump")
+
+	var buf bytes.Buffer
+	if err := Dump(&buf); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	expected := "=== dump (running)\nElapsed: 1s\n"
+	if !strings.HasPrefix(buf.String(), expected) {
+		t.Errorf("Dump: got:\n%s\nexpected prefix:\n%s\n", buf.String(), expected)
+	}
+
+	var out syncBuffer
+	stop := DumpOnSignal(syscall.SIGUSR1, &out)
+	defer stop()
+
+	p, _ := os.FindProcess(os.Getpid())
+	p.Signal(syscall.SIGUSR1)
+
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(out.String(), "=== dump") {
+		if time.Now().After(deadline) {
+			t.Fatal("DumpOnSignal: nothing was dumped")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	stop()
+}
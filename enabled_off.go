@@ -0,0 +1,8 @@
+//go:build stopwatch_off
+// +build stopwatch_off
+
+package stopwatch
+
+// Enabled reports whether stopwatches measure anything. It is false if the
+// package is built with the stopwatch_off build tag, stopwatches never start.
+const Enabled = false
@@ -0,0 +1,62 @@
+//go:build stopwatch_off
+// +build stopwatch_off
+
+package stopwatch
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_Off(t *testing.T) {
+	sw := Start(0)
+	defer sw.Section("load")()
+	sw.SetTotal(10)
+	sw.Advance(5)
+
+	time.Sleep(time.Millisecond)
+	if lap := sw.Lap(); lap != 0 {
+		t.Errorf("Lap: got: %s expected: 0\n", lap)
+	}
+	sw.Stop()
+
+	if !sw.IsReseted() {
+		t.Error("Start: a disabled stopwatch should never be started")
+	}
+	if d := sw.ElapsedTime(); d != 0 {
+		t.Errorf("ElapsedTime: got: %s expected: 0\n", d)
+	}
+	if len(sw.Laps()) != 0 || len(sw.Sections()) != 0 {
+		t.Errorf("Stopwatch: got laps: %v sections: %v expected none\n", sw.Laps(), sw.Sections())
+	}
+}
+
+func TestStopwatch_OffRestore(t *testing.T) {
+	var sw Stopwatch
+	if err := json.Unmarshal([]byte(`"1h"`), &sw); err != nil {
+		t.Fatalf("UnmarshalJSON: error: %s\n", err)
+	}
+	sw.SetElapsed(time.Hour)
+	sw.AddElapsed(time.Hour)
+	sw.StartAt(time.Now())
+	sw.Tick(1)
+	sw.LapWithTags(map[string]string{"k": "v"})
+
+	checkpoint := `{"version":1,"state":"running","elapsed_ns":3600000000000,"laps":[{"Seq":1,"Duration":1000}],"sections":[{"name":"load","start":"2014-02-10T00:00:00Z"}]}`
+	restored, err := ReadCheckpoint(strings.NewReader(checkpoint))
+	if err != nil {
+		t.Fatalf("ReadCheckpoint: error: %s\n", err)
+	}
+
+	time.Sleep(10 * time.Millisecond) // for StartAt
+	for _, s := range []*Stopwatch{&sw, restored} {
+		if !s.IsReseted() || s.ElapsedTime() != 0 {
+			t.Errorf("Stopwatch: a disabled stopwatch should never be restored, got %s\n", s.ElapsedTime())
+		}
+		if len(s.Laps()) != 0 || len(s.Sections()) != 0 || s.Ticks() != 0 {
+			t.Errorf("Stopwatch: got laps: %v sections: %v ticks: %d expected none\n", s.Laps(), s.Sections(), s.Ticks())
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package stopwatch
+
+import "time"
+
+// EventKind describes what happened to a stopwatch.
+type EventKind int
+
+const (
+	EventStart EventKind = iota
+	EventStop
+	EventReset
+	EventLap
+	EventSectionStart
+	EventSectionEnd
+
+	// EventWatchdog, EventDeadline and EventBudget are delivered once a
+	// threshold is crossed, see WithWatchdog, SetDeadline and SetBudget.
+	// They carry a postmortem if enabled with WithPostmortem.
+	EventWatchdog
+	EventDeadline
+	EventBudget
+)
+
+var eventKindNames = map[EventKind]string{
+	EventStart:        "start",
+	EventStop:         "stop",
+	EventReset:        "reset",
+	EventLap:          "lap",
+	EventSectionStart: "section_start",
+	EventSectionEnd:   "section_end",
+	EventWatchdog:     "watchdog",
+	EventDeadline:     "deadline",
+	EventBudget:       "budget",
+}
+
+func (k EventKind) String() string {
+	if name, ok := eventKindNames[k]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Event is delivered to the hooks registered with OnEvent.
+type Event struct {
+	// Seq is the sequence number of the event. It increases monotonically
+	// for each event of a stopwatch, which allows consumers to detect lost or
+	// reordered events.
+	Seq uint64
+
+	Kind    EventKind
+	Time    time.Time
+	Elapsed time.Duration // elapsed time of the stopwatch at Time
+
+	// Duration is the lap duration for EventLap, the section duration for
+	// EventSectionEnd, the overrun for EventDeadline and the total time of
+	// the section for EventBudget.
+	Duration time.Duration
+
+	// Section is the slash separated path of the section for section events
+	// and EventBudget, such as "load/parse".
+	Section string
+
+	// Tags are the tags of the stopwatch, together with the tags of the lap
+	// for EventLap, see SetTag and LapWithTags.
+	Tags map[string]string
+
+	// Postmortem is the snapshot taken with threshold events, see
+	// WithPostmortem.
+	Postmortem *Postmortem
+}
+
+// hook is a function registered with OnEvent.
+type hook struct {
+	id uint64
+	fn func(Event)
+}
+
+// OnEvent registers fn to be called for every event of the stopwatch. Hooks
+// are called synchronously, in the order they were registered, by the
+// goroutine that caused the event. The stopwatch is not locked while hooks
+// run, so they may call its methods. The returned function removes the hook,
+// it can be called more than once.
+func (s *Stopwatch) OnEvent(fn func(Event)) (remove func()) {
+	s.mu.Lock()
+	s.lastID++
+	id := s.lastID
+	s.hooks = append(s.hooks, hook{id: id, fn: fn})
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		// the hooks are copied, unlock may be delivering the old ones
+		hooks := make([]hook, 0, len(s.hooks))
+		for _, h := range s.hooks {
+			if h.id != id {
+				hooks = append(hooks, h)
+			}
+		}
+		s.hooks = hooks
+	}
+}
+
+// event returns an event of the given kind for the current state and assigns
+// it the next sequence number. The lock must be held.
+func (s *Stopwatch) event(kind EventKind) Event {
+	s.seq++
+	return Event{
+		Seq:     s.seq,
+		Kind:    kind,
+		Time:    s.now(),
+		Elapsed: s.elapsed(),
+		Tags:    mergeTags(s.tags, nil),
+	}
+}
+
+// unlock releases the lock and delivers the given events to the hooks.
+func (s *Stopwatch) unlock(events ...Event) {
+	hooks := s.hooks
+	s.mu.Unlock()
+
+	for _, e := range events {
+		for _, h := range hooks {
+			h.fn(e)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_OnEvent(t *testing.T) {
+	sw := New()
+
+	var events []Event
+	sw.OnEvent(func(e Event) { events = append(events, e) })
+
+	sw.Start(0)
+	end := sw.Section("a")
+	sw.Lap()
+	sw.Section("b")
+	end()
+	sw.Stop()
+	sw.Stop() // no-op
+	sw.Reset()
+
+	expected := []EventKind{EventStart, EventSectionStart, EventLap, EventSectionStart,
+		EventSectionEnd, EventSectionEnd, EventStop, EventReset}
+
+	if len(events) != len(expected) {
+		t.Fatalf("OnEvent: got: %d events expected: %d\n", len(events), len(expected))
+	}
+
+	for i, e := range events {
+		if e.Kind != expected[i] {
+			t.Errorf("OnEvent: event %d got: %s expected: %s\n", i, e.Kind, expected[i])
+		}
+	}
+
+	if events[4].Section != "a/b" || events[5].Section != "a" {
+		t.Errorf("OnEvent: got sections %q %q expected: a/b a\n", events[4].Section, events[5].Section)
+	}
+}
+
+func TestStopwatch_OnEventRemove(t *testing.T) {
+	sw := Start(0)
+
+	var a, b int
+	removeA := sw.OnEvent(func(e Event) { a++ })
+	sw.OnEvent(func(e Event) { b++ })
+	sw.Lap()
+
+	removeA()
+	removeA()
+	sw.Lap()
+
+	if a != 1 || b != 2 || hookCount(sw) != 1 {
+		t.Errorf("OnEvent: got: %d %d calls %d hooks expected: 1 2 calls 1 hook\n", a, b, hookCount(sw))
+	}
+}
+
+func TestStopwatch_OnEventReentrant(t *testing.T) {
+	sw := Start(0)
+
+	var elapsed time.Duration
+	sw.OnEvent(func(e Event) { elapsed = sw.ElapsedTime() })
+	sw.Lap()
+
+	if elapsed <= 0 {
+		t.Error("OnEvent: hooks should be able to call the stopwatch")
+	}
+}
+
+func TestStopwatch_Concurrent(t *testing.T) {
+	sw := Start(0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				sw.Lap()
+				sw.ElapsedTime()
+				sw.Sections()
+				sw.Section("work")()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := len(sw.Laps()); n != 400 {
+		t.Errorf("Concurrent: got: %d laps expected: %d\n", n, 400)
+	}
+}
+
+func TestStopwatch_EventSeq(t *testing.T) {
+	sw := New()
+
+	var seqs []uint64
+	sw.OnEvent(func(e Event) { seqs = append(seqs, e.Seq) })
+
+	sw.Start(0)
+	sw.Lap()
+	sw.Lap()
+	sw.Reset()
+	sw.Start(0)
+	sw.Lap()
+
+	for i, seq := range seqs {
+		if seq != uint64(i+1) {
+			t.Errorf("EventSeq: event %d got: %d expected: %d\n", i, seq, i+1)
+		}
+	}
+
+	laps := sw.LapRecords()
+	if len(laps) != 1 || laps[0].Seq != seqs[len(seqs)-1] {
+		t.Errorf("EventSeq: lap record %v should carry the sequence number of its event\n", laps)
+	}
+}
@@ -0,0 +1,295 @@
+package stopwatch
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Expectations declares the maximum durations of a session. They are usually
+// loaded from a JSON file such as:
+//
+//	{
+//		"total": "10s",
+//		"sections": {"load": "2s", "load/parse": "500ms"}
+//	}
+//
+// or from the same YAML file:
+//
+//	total: 10s
+//	sections:
+//	  load: 2s
+//	  load/parse: 500ms
+//
+// Sections are identified by their slash separated path.
+type Expectations struct {
+	Total    time.Duration            // maximum elapsed time, zero for no limit
+	Sections map[string]time.Duration // maximum duration of each section
+}
+
+// Violation is an expectation that is not met by a session.
+type Violation struct {
+	Name    string // section path, empty for the total
+	Max     time.Duration
+	Elapsed time.Duration
+	Missing bool // the section was never opened
+}
+
+func (v Violation) String() string {
+	name := v.Name
+	if name == "" {
+		name = "total"
+	}
+
+	if v.Missing {
+		return fmt.Sprintf("%s: missing, expected at most %s", name, v.Max)
+	}
+	return fmt.Sprintf("%s: took %s, expected at most %s", name, v.Elapsed, v.Max)
+}
+
+// rawExpectations are expectations with the durations not yet parsed.
+type rawExpectations struct {
+	Total    string            `json:"total"`
+	Sections map[string]string `json:"sections"`
+}
+
+// LoadExpectations reads expectations from the file at path. Files with a
+// ".yaml" or ".yml" extension are read as YAML, see ParseExpectationsYAML,
+// all others as JSON.
+func LoadExpectations(path string) (*Expectations, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return ParseExpectationsYAML(f)
+	}
+	return ParseExpectations(f)
+}
+
+// ParseExpectations reads JSON encoded expectations from r. Durations are
+// strings that can be parsed with time.ParseDuration.
+func ParseExpectations(r io.Reader) (*Expectations, error) {
+	var raw rawExpectations
+
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("stopwatch: expectations: %w", err)
+	}
+
+	return raw.parse()
+}
+
+// ParseExpectationsYAML reads YAML encoded expectations from r, like
+// ParseExpectations. Only the block mappings of the expectations are
+// supported, with plain or quoted scalars and comments, not the whole of
+// YAML.
+func ParseExpectationsYAML(r io.Reader) (*Expectations, error) {
+	var raw rawExpectations
+	inSections, indent := false, 0
+
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := stripYAMLComment(sc.Text())
+		content := strings.TrimLeft(line, " ")
+		if content == "" || (n == 1 && content == "---") {
+			continue
+		}
+		if strings.HasPrefix(content, "\t") {
+			return nil, fmt.Errorf("stopwatch: expectations: line %d: tabs are not allowed in indentation", n)
+		}
+
+		key, value, err := splitYAMLPair(content)
+		if err != nil {
+			return nil, fmt.Errorf("stopwatch: expectations: line %d: %w", n, err)
+		}
+
+		if lead := len(line) - len(content); lead > 0 {
+			if !inSections || (indent > 0 && lead != indent) {
+				return nil, fmt.Errorf("stopwatch: expectations: line %d: unexpected indentation", n)
+			}
+			indent = lead
+			if raw.Sections == nil {
+				raw.Sections = make(map[string]string)
+			}
+			raw.Sections[key] = value
+			continue
+		}
+
+		inSections = false
+		switch key {
+		case "total":
+			raw.Total = value
+		case "sections":
+			if value != "" {
+				return nil, fmt.Errorf("stopwatch: expectations: line %d: sections must be a mapping", n)
+			}
+			inSections, indent = true, 0
+		default:
+			return nil, fmt.Errorf("stopwatch: expectations: line %d: unknown field %q", n, key)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("stopwatch: expectations: %w", err)
+	}
+
+	return raw.parse()
+}
+
+// stripYAMLComment removes a trailing comment from line. A comment starts
+// with a "#" at the start of the line or after a space, outside of quotes.
+func stripYAMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return strings.TrimRight(line[:i], " \t")
+		}
+	}
+	return strings.TrimRight(line, " \t")
+}
+
+// splitYAMLPair splits a "key: value" line of a block mapping and unquotes
+// both.
+func splitYAMLPair(s string) (key, value string, err error) {
+	rest := s
+	if s[0] == '"' || s[0] == '\'' {
+		end := closingQuote(s)
+		if end < 0 {
+			return "", "", fmt.Errorf("unterminated key %s", s)
+		}
+		rest = s[end+1:]
+		if key, err = unquoteYAML(s[:end+1]); err != nil {
+			return "", "", err
+		}
+		if !strings.HasPrefix(rest, ":") {
+			return "", "", fmt.Errorf("expected a key: value pair: %s", s)
+		}
+		rest = rest[1:]
+	} else {
+		i := strings.Index(s, ": ")
+		if i < 0 && strings.HasSuffix(s, ":") {
+			i = len(s) - 1
+		}
+		if i < 0 {
+			return "", "", fmt.Errorf("expected a key: value pair: %s", s)
+		}
+		key, rest = s[:i], s[i+1:]
+	}
+
+	if rest != "" && rest[0] != ' ' {
+		return "", "", fmt.Errorf("expected a key: value pair: %s", s)
+	}
+	value, err = unquoteYAML(strings.TrimSpace(rest))
+	return key, value, err
+}
+
+// closingQuote returns the index of the quote closing the scalar at the start
+// of s, or -1.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[0] == '"' && s[i] == '\\':
+			i++
+		case s[i] == s[0] && s[0] == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++ // escaped single quote
+		case s[i] == s[0]:
+			return i
+		}
+	}
+	return -1
+}
+
+// unquoteYAML returns the value of a plain, single or double quoted scalar.
+func unquoteYAML(s string) (string, error) {
+	switch {
+	case len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"':
+		return strconv.Unquote(s)
+	case len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'':
+		return strings.Replace(s[1:len(s)-1], "''", "'", -1), nil
+	case strings.HasPrefix(s, "{") || strings.HasPrefix(s, "["):
+		return "", fmt.Errorf("flow collections are not supported: %s", s)
+	}
+	return s, nil
+}
+
+// parse returns the expectations with the durations parsed.
+func (raw rawExpectations) parse() (*Expectations, error) {
+	e := &Expectations{Sections: make(map[string]time.Duration, len(raw.Sections))}
+	if raw.Total != "" {
+		d, err := time.ParseDuration(raw.Total)
+		if err != nil {
+			return nil, fmt.Errorf("stopwatch: expectations: total: %w", err)
+		}
+		e.Total = d
+	}
+
+	for name, v := range raw.Sections {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("stopwatch: expectations: section %q: %w", name, err)
+		}
+		e.Sections[name] = d
+	}
+
+	return e, nil
+}
+
+// CheckExpectations returns the expectations not met by the session, in the
+// order the sections were opened, followed by the sections that were never
+// opened, sorted by name. A section opened more than once is checked every
+// time. It returns nil if all expectations are met.
+func (s *Stopwatch) CheckExpectations(e *Expectations) []Violation {
+	r := s.report(0)
+
+	var violations []Violation
+	if e.Total > 0 && r.Elapsed > e.Total {
+		violations = append(violations, Violation{Max: e.Total, Elapsed: r.Elapsed})
+	}
+
+	seen := make(map[string]bool)
+	for _, c := range r.Sections {
+		max, ok := e.Sections[c.Name]
+		if !ok {
+			continue
+		}
+
+		seen[c.Name] = true
+		if c.Elapsed > max {
+			violations = append(violations, Violation{Name: c.Name, Max: max, Elapsed: c.Elapsed})
+		}
+	}
+
+	var missing []string
+	for name := range e.Sections {
+		if !seen[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+
+	for _, name := range missing {
+		violations = append(violations, Violation{Name: name, Max: e.Sections[name], Missing: true})
+	}
+
+	return violations
+}
@@ -0,0 +1,109 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_CheckExpectations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "expectations.json")
+	os.WriteFile(path, []byte(`{
+		"total": "3s",
+		"sections": {"load": "2s", "load/parse": "500ms", "run": "10s", "upload": "1s"}
+	}`), 0644)
+
+	e, err := LoadExpectations(path)
+	if err != nil {
+		t.Fatalf("LoadExpectations: error: %s\n", err)
+	}
+
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	end := sw.Section("load")
+	endParse := sw.Section("parse")
+	c.Advance(time.Second)
+	endParse()
+	end()
+	done := sw.Section("run")
+	c.Advance(3 * time.Second)
+	done()
+
+	got := sw.CheckExpectations(e)
+	expected := []string{
+		"total: took 4s, expected at most 3s",
+		"load/parse: took 1s, expected at most 500ms",
+		"upload: missing, expected at most 1s",
+	}
+
+	if len(got) != len(expected) {
+		t.Fatalf("CheckExpectations: got: %v expected: %v\n", got, expected)
+	}
+
+	for i, v := range got {
+		if v.String() != expected[i] {
+			t.Errorf("CheckExpectations: got: %s expected: %s\n", v, expected[i])
+		}
+	}
+
+	if v := sw.CheckExpectations(&Expectations{Total: time.Minute}); v != nil {
+		t.Errorf("CheckExpectations: got: %v expected: nil\n", v)
+	}
+}
+
+func TestParseExpectations(t *testing.T) {
+	for _, input := range []string{
+		`{"total": "soon"}`,
+		`{"sections": {"load": "2"}}`,
+		`{"totl": "2s"}`,
+		`[]`,
+	} {
+		if _, err := ParseExpectations(strings.NewReader(input)); err == nil {
+			t.Errorf("ParseExpectations: expected an error for %s\n", input)
+		}
+	}
+}
+
+func TestParseExpectationsYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "expectations.yaml")
+	os.WriteFile(path, []byte(`---
+# the build gate
+total: 10s # wall time
+sections:
+  load: 2s
+  "load/parse": '500ms'
+  "run #1": "1m"
+`), 0644)
+
+	e, err := LoadExpectations(path)
+	if err != nil {
+		t.Fatalf("LoadExpectations: error: %s\n", err)
+	}
+
+	expected := map[string]time.Duration{"load": 2 * time.Second, "load/parse": 500 * time.Millisecond, "run #1": time.Minute}
+	if e.Total != 10*time.Second || !reflect.DeepEqual(e.Sections, expected) {
+		t.Errorf("ParseExpectationsYAML: got: %s %v expected: 10s %v\n", e.Total, e.Sections, expected)
+	}
+
+	for _, input := range []string{
+		"total: soon",
+		"totl: 2s",
+		"sections: {load: 2s}",
+		"  load: 2s",
+		"total: 2s\n  load: 2s",
+		"sections:\n  load: 2s\n    parse: 1s",
+		"sections:\n\tload: 2s",
+		"total",
+		`"total: 2s`,
+	} {
+		if _, err := ParseExpectationsYAML(strings.NewReader(input)); err == nil {
+			t.Errorf("ParseExpectationsYAML: expected an error for %q\n", input)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package stopwatch
+
+import "expvar"
+
+// Publish exposes the stopwatch under the given name via the expvar package,
+// so it is served by /debug/vars. Like expvar.Publish it panics if the name
+// is already in use.
+func (s *Stopwatch) Publish(name string) {
+	expvar.Publish(name, expvar.Func(s.expvar))
+}
+
+// expvar returns the value exposed by Publish.
+func (s *Stopwatch) expvar() interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elapsed := s.elapsed()
+	v := map[string]interface{}{
+		"elapsed":    elapsed.String(),
+		"elapsed_ns": int64(elapsed),
+		"running":    s.isRunning(),
+		"laps":       s.lapStats.Count,
+	}
+
+	if stats := s.lapStats; stats.Count > 0 {
+		v["lap_total_ns"] = int64(stats.Total)
+		v["lap_min_ns"] = int64(stats.Min)
+		v["lap_max_ns"] = int64(stats.Max)
+		v["lap_avg_ns"] = int64(stats.Mean())
+	}
+
+	return v
+}
@@ -0,0 +1,37 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_Publish(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	sw.Publish("test_stopwatch_publish")
+
+	c.Advance(time.Second)
+	sw.Lap()
+	c.Advance(3 * time.Second)
+	sw.Lap()
+	sw.Stop()
+
+	var v struct {
+		Elapsed string `json:"elapsed"`
+		Running bool   `json:"running"`
+		Laps    int    `json:"laps"`
+		Avg     int64  `json:"lap_avg_ns"`
+	}
+	if err := json.Unmarshal([]byte(expvar.Get("test_stopwatch_publish").String()), &v); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	if v.Elapsed != "4s" || v.Running || v.Laps != 2 || time.Duration(v.Avg) != 2*time.Second {
+		t.Errorf("Publish: unexpected value %+v\n", v)
+	}
+}
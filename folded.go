@@ -0,0 +1,55 @@
+package stopwatch
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ExportFolded writes the sections in the folded stack format used by
+// flamegraph.pl and speedscope. Each line consists of the semicolon separated
+// section names followed by the self time of the stack in nanoseconds.
+// Example output: load;parse 1234567
+func (s *Stopwatch) ExportFolded(w io.Writer) error {
+	var stacks []string
+	values := make(map[string]time.Duration)
+
+	var walk func(prefix string, sections []*Section)
+	walk = func(prefix string, sections []*Section) {
+		for _, c := range sections {
+			stack := prefix + foldedName(c.Name)
+
+			self := c.Elapsed()
+			for _, child := range c.Children {
+				self -= child.Elapsed()
+			}
+			if self < 0 {
+				self = 0
+			}
+
+			if _, ok := values[stack]; !ok {
+				stacks = append(stacks, stack)
+			}
+			values[stack] += self
+
+			walk(stack+";", c.Children)
+		}
+	}
+	s.mu.Lock()
+	walk("", s.sections)
+	s.mu.Unlock()
+
+	for _, stack := range stacks {
+		if _, err := fmt.Fprintf(w, "%s %d\n", stack, int64(values[stack])); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// foldedName escapes characters that have a meaning in the folded format.
+func foldedName(name string) string {
+	return strings.NewReplacer(";", "_", "\n", " ").Replace(name)
+}
@@ -0,0 +1,38 @@
+package stopwatch
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_ExportFolded(t *testing.T) {
+	sw := Start(0)
+	start := time.Now()
+
+	root := &Section{Name: "load", Start: start, End: start.Add(100 * time.Millisecond)}
+	root.Children = []*Section{
+		{Name: "parse", Start: start, End: start.Add(30 * time.Millisecond), parent: root},
+		{Name: "parse", Start: start, End: start.Add(20 * time.Millisecond), parent: root},
+		{Name: "a;b", Start: start, End: start.Add(10 * time.Millisecond), parent: root},
+	}
+	sw.sections = []*Section{root}
+
+	var buf bytes.Buffer
+	if err := sw.ExportFolded(&buf); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	expected := []string{
+		"load " + strconv.Itoa(int(40*time.Millisecond)),
+		"load;parse " + strconv.Itoa(int(50*time.Millisecond)),
+		"load;a_b " + strconv.Itoa(int(10*time.Millisecond)),
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("ExportFolded: got:\n%s\nexpected:\n%s\n", buf.String(), strings.Join(expected, "\n"))
+	}
+}
@@ -0,0 +1,80 @@
+package stopwatch
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// durationUnits are the units used by HumanDuration, the largest first.
+var durationUnits = []struct {
+	d      time.Duration
+	suffix string
+	width  int // zero padding after a larger unit
+}{
+	{24 * time.Hour, "d", 0},
+	{time.Hour, "h", 0},
+	{time.Minute, "m", 2},
+	{time.Second, "s", 2},
+	{time.Millisecond, "ms", 3},
+}
+
+// HumanDuration formats d in a readable form such as "1h 02m 03s" or
+// "2d 4h", instead of "3723s" for long durations. d is truncated to a
+// multiple of granularity, which also sets the smallest unit shown.
+// Granularities below a millisecond are treated as a millisecond. Trailing
+// zero units are left out.
+func HumanDuration(d, granularity time.Duration) string {
+	if granularity < time.Millisecond {
+		granularity = time.Millisecond
+	}
+
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	d = d.Truncate(granularity)
+
+	var parts []string
+	last := 0 // number of parts up to the last non-zero unit
+	for _, u := range durationUnits {
+		n := d / u.d
+		d -= n * u.d
+
+		if len(parts) > 0 || n > 0 {
+			s := strconv.FormatInt(int64(n), 10)
+			if len(parts) > 0 && len(s) < u.width {
+				s = strings.Repeat("0", u.width-len(s)) + s
+			}
+			parts = append(parts, s+u.suffix)
+			if n > 0 {
+				last = len(parts)
+			}
+		}
+
+		if u.d <= granularity {
+			if len(parts) == 0 {
+				return "0" + u.suffix
+			}
+			break
+		}
+	}
+
+	return sign + strings.Join(parts[:last], " ")
+}
+
+// WithHumanDurations makes Print, Log, String and the Markdown and plain
+// text reports format durations with HumanDuration at the given granularity.
+func WithHumanDurations(granularity time.Duration) Option {
+	return func(s *Stopwatch) {
+		s.format = func(d time.Duration) string { return HumanDuration(d, granularity) }
+	}
+}
+
+// formatDuration formats d for humans, see WithHumanDurations.
+func (s *Stopwatch) formatDuration(d time.Duration) string {
+	if s.format == nil {
+		return d.String()
+	}
+	return s.format(d)
+}
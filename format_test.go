@@ -0,0 +1,55 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHumanDuration(t *testing.T) {
+	tests := []struct {
+		d, granularity time.Duration
+		expected       string
+	}{
+		{time.Hour + 2*time.Minute + 3*time.Second, time.Second, "1h 02m 03s"},
+		{52 * time.Hour, time.Second, "2d 4h"},
+		{93784*time.Second + 1, time.Second, "1d 2h 03m 04s"},
+		{93784*time.Second + 1, time.Hour, "1d 2h"},
+		{time.Hour + 3*time.Second, time.Second, "1h 00m 03s"},
+		{1500 * time.Millisecond, time.Millisecond, "1s 500ms"},
+		{1500 * time.Millisecond, time.Nanosecond, "1s 500ms"},
+		{59 * time.Second, time.Minute, "0m"},
+		{0, time.Second, "0s"},
+		{-90 * time.Second, time.Second, "-1m 30s"},
+	}
+
+	for _, tt := range tests {
+		if got := HumanDuration(tt.d, tt.granularity); got != tt.expected {
+			t.Errorf("HumanDuration(%s, %s): got: %q expected: %q\n", tt.d, tt.granularity, got, tt.expected)
+		}
+	}
+}
+
+func TestStopwatch_WithHumanDurations(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c), WithHumanDurations(time.Second))
+
+	c.Advance(time.Hour + 2*time.Minute + 3*time.Second)
+	sw.Lap()
+
+	if !strings.Contains(sw.String(), "elapsed: 1h 02m 03s]") {
+		t.Errorf("String: got: %s expected a human duration\n", sw.String())
+	}
+
+	var buf bytes.Buffer
+	if err := sw.Report(&buf, ReportText); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	if !strings.Contains(buf.String(), "Elapsed: 1h 02m 03s") || !strings.Contains(buf.String(), "1    1h 02m 03s") {
+		t.Errorf("Report: got:\n%s\nexpected human durations\n", buf.String())
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/fatih/stopwatch
+
+go 1.18
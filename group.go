@@ -0,0 +1,85 @@
+package stopwatch
+
+import (
+	"sync"
+	"time"
+)
+
+// Group aggregates the timings of a set of stopwatches, such as one per
+// goroutine of a fan-out. Each goroutine gets its own stopwatch from Watch,
+// so none of them share mutable state.
+type Group struct {
+	mu      sync.Mutex
+	opts    []Option
+	watches []*Stopwatch
+	wg      sync.WaitGroup
+}
+
+// GroupResult is the aggregated timing of the stopwatches of a Group.
+type GroupResult struct {
+	Total    time.Duration   // sum of the elapsed times
+	Min, Max time.Duration   // shortest and longest elapsed time
+	Watches  []time.Duration // elapsed time of each stopwatch, in Watch order
+}
+
+// NewGroup creates a new group. The options are applied to every stopwatch
+// of the group.
+func NewGroup(opts ...Option) *Group {
+	return &Group{opts: opts}
+}
+
+// Watch starts and returns a new stopwatch of the group. It is done once it is
+// stopped or reseted for the first time, see Wait. Returning it to a Pool
+// resets it.
+// Example : w := g.Watch(); go func() { defer w.Stop(); work() }()
+func (g *Group) Watch() *Stopwatch {
+	s := Start(0, g.opts...)
+
+	g.wg.Add(1)
+	var once sync.Once
+	var remove func()
+	remove = s.OnEvent(func(e Event) {
+		if e.Kind == EventStop || e.Kind == EventReset {
+			once.Do(func() {
+				remove()
+				g.wg.Done()
+			})
+		}
+	})
+
+	g.mu.Lock()
+	g.watches = append(g.watches, s)
+	g.mu.Unlock()
+
+	return s
+}
+
+// Wait blocks until every stopwatch of the group is stopped or reseted and
+// returns the aggregated timing.
+func (g *Group) Wait() GroupResult {
+	g.wg.Wait()
+	return g.Result()
+}
+
+// Result returns the aggregated timing of the stopwatches so far, without
+// waiting for them to stop.
+func (g *Group) Result() GroupResult {
+	g.mu.Lock()
+	watches := make([]*Stopwatch, len(g.watches))
+	copy(watches, g.watches)
+	g.mu.Unlock()
+
+	r := GroupResult{Watches: make([]time.Duration, 0, len(watches))}
+	for i, s := range watches {
+		d := s.ElapsedTime()
+		r.Watches = append(r.Watches, d)
+		r.Total += d
+		if i == 0 || d < r.Min {
+			r.Min = d
+		}
+		if d > r.Max {
+			r.Max = d
+		}
+	}
+	return r
+}
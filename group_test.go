@@ -0,0 +1,71 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGroup(t *testing.T) {
+	c := newClock()
+	g := NewGroup(WithClock(c))
+
+	a, b := g.Watch(), g.Watch()
+	c.Advance(time.Second)
+
+	done := make(chan GroupResult)
+	go func() { done <- g.Wait() }()
+
+	a.Stop()
+	c.Advance(2 * time.Second)
+
+	select {
+	case <-done:
+		t.Fatal("Wait: returned before all stopwatches were stopped")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	b.Stop()
+	r := <-done
+
+	expected := GroupResult{
+		Total:   4 * time.Second,
+		Min:     time.Second,
+		Max:     3 * time.Second,
+		Watches: []time.Duration{time.Second, 3 * time.Second},
+	}
+	if !reflect.DeepEqual(r, expected) {
+		t.Errorf("Wait: got: %+v expected: %+v\n", r, expected)
+	}
+
+	if hookCount(a) != 0 || hookCount(b) != 0 {
+		t.Errorf("Wait: got: %d %d hooks after stop expected: 0\n", hookCount(a), hookCount(b))
+	}
+
+	// stopping again must not release the group twice
+	a.Start(0)
+	a.Stop()
+}
+
+func TestGroup_Reset(t *testing.T) {
+	g := NewGroup()
+
+	a, b := g.Watch(), g.Watch()
+	a.Reset()
+	NewPool().Put(b)
+
+	done := make(chan struct{})
+	go func() {
+		g.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Wait: should return once the stopwatches are reseted or put into a pool")
+	}
+}
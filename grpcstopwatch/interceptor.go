@@ -0,0 +1,165 @@
+// Package grpcstopwatch provides gRPC interceptors that time every RPC with a
+// stopwatch. The stopwatch is stored in the RPC context and can be retrieved
+// with stopwatch.FromContext, analogous to stopwatch.Middleware.
+package grpcstopwatch
+
+import (
+	"context"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/fatih/stopwatch"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Reporter is called with the result of every completed RPC.
+type Reporter func(method string, elapsed time.Duration, err error)
+
+// Option configures the interceptors.
+type Option func(*config)
+
+// WithReporter sets the function the results are reported to. By default they
+// are logged with log.Printf.
+func WithReporter(fn Reporter) Option {
+	return func(c *config) { c.report = fn }
+}
+
+// WithStopwatchOptions sets the options of the stopwatches created for each
+// RPC.
+func WithStopwatchOptions(opts ...stopwatch.Option) Option {
+	return func(c *config) { c.opts = opts }
+}
+
+type config struct {
+	report Reporter
+	opts   []stopwatch.Option
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		report: func(method string, elapsed time.Duration, err error) {
+			log.Printf("%s %s - elapsed: %s\n", method, status.Code(err), elapsed)
+		},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// start creates the stopwatch of an RPC and stores it in ctx.
+func (c *config) start(ctx context.Context) (context.Context, *stopwatch.Stopwatch) {
+	s := stopwatch.Start(0, c.opts...)
+	return stopwatch.NewContext(ctx, s), s
+}
+
+// finish stops s and reports the result.
+func (c *config) finish(method string, s *stopwatch.Stopwatch, err error) {
+	s.Stop()
+	c.report(method, s.ElapsedTime(), err)
+}
+
+// UnaryServerInterceptor returns a server interceptor timing unary RPCs.
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	c := newConfig(opts)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, s := c.start(ctx)
+		resp, err := handler(ctx, req)
+		c.finish(info.FullMethod, s, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a server interceptor timing streaming RPCs.
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	c := newConfig(opts)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, s := c.start(ss.Context())
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		c.finish(info.FullMethod, s, err)
+		return err
+	}
+}
+
+// UnaryClientInterceptor returns a client interceptor timing unary RPCs.
+func UnaryClientInterceptor(opts ...Option) grpc.UnaryClientInterceptor {
+	c := newConfig(opts)
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		ctx, s := c.start(ctx)
+		err := invoker(ctx, method, req, reply, cc, callOpts...)
+		c.finish(method, s, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor returns a client interceptor timing streaming RPCs.
+// A stream is done once receiving from it returns an error or io.EOF, after
+// the single response of a client-streaming RPC, or once its context is done,
+// such as for a stream the caller abandoned.
+func StreamClientInterceptor(opts ...Option) grpc.StreamClientInterceptor {
+	c := newConfig(opts)
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, s := c.start(ctx)
+		cs, err := streamer(ctx, desc, cc, method, callOpts...)
+		if err != nil {
+			c.finish(method, s, err)
+			return nil, err
+		}
+
+		stream := &clientStream{
+			ClientStream:  cs,
+			serverStreams: desc.ServerStreams,
+			finished:      make(chan struct{}),
+			done:          func(err error) { c.finish(method, s, err) },
+		}
+		go func() {
+			select {
+			case <-ctx.Done():
+				stream.finish(status.FromContextError(ctx.Err()).Err())
+			case <-stream.finished:
+			}
+		}()
+		return stream, nil
+	}
+}
+
+// serverStream overrides the context of a grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context { return s.ctx }
+
+// clientStream reports once the stream is done.
+type clientStream struct {
+	grpc.ClientStream
+	serverStreams bool // false if the stream ends with a single response
+	once          sync.Once
+	finished      chan struct{}
+	done          func(error)
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == io.EOF:
+		s.finish(nil)
+	case err != nil || !s.serverStreams:
+		s.finish(err)
+	}
+	return err
+}
+
+// finish reports the stream with err, only the first call has an effect.
+func (s *clientStream) finish(err error) {
+	s.once.Do(func() {
+		close(s.finished)
+		s.done(err)
+	})
+}
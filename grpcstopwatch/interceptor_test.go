@@ -0,0 +1,198 @@
+package grpcstopwatch
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fatih/stopwatch"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type result struct {
+	method string
+	err    error
+}
+
+type recorder struct {
+	mu      sync.Mutex
+	results []result
+}
+
+func (r *recorder) report(method string, elapsed time.Duration, err error) {
+	r.mu.Lock()
+	r.results = append(r.results, result{method, err})
+	r.mu.Unlock()
+}
+
+func (r *recorder) get() []result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]result(nil), r.results...)
+}
+
+// checker fails if the context does not carry a stopwatch.
+type checker struct {
+	healthpb.HealthServer
+	t *testing.T
+}
+
+func (c checker) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if stopwatch.FromContext(ctx) == nil {
+		c.t.Error("Interceptor: the context should carry a stopwatch")
+	}
+	return c.HealthServer.Check(ctx, req)
+}
+
+// sumDesc describes a client-streaming RPC summing the numbers it receives.
+var sumDesc = grpc.ServiceDesc{
+	ServiceName: "test.Sum",
+	HandlerType: (*interface{})(nil),
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "Sum",
+		ClientStreams: true,
+		Handler: func(srv interface{}, stream grpc.ServerStream) error {
+			var sum int64
+			for {
+				var n wrapperspb.Int64Value
+				if err := stream.RecvMsg(&n); err == io.EOF {
+					return stream.SendMsg(wrapperspb.Int64(sum))
+				} else if err != nil {
+					return err
+				}
+				sum += n.Value
+			}
+		},
+	}},
+}
+
+// serve starts a server with the health and sum services, both ends
+// reporting to the given recorders, and returns a connection to it.
+func serve(t *testing.T, server, client *recorder) *grpc.ClientConn {
+	lis := bufconn.Listen(1 << 16)
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor(WithReporter(server.report))),
+		grpc.StreamInterceptor(StreamServerInterceptor(WithReporter(server.report))),
+	)
+	healthpb.RegisterHealthServer(srv, checker{health.NewServer(), t})
+	srv.RegisterService(&sumDesc, struct{}{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor(WithReporter(client.report))),
+		grpc.WithStreamInterceptor(StreamClientInterceptor(WithReporter(client.report))),
+	)
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// waitResults waits until r has n results.
+func waitResults(r *recorder, n int) []result {
+	deadline := time.Now().Add(time.Second)
+	for len(r.get()) < n && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	return r.get()
+}
+
+func TestInterceptors(t *testing.T) {
+	var server, client recorder
+	conn := serve(t, &server, &client)
+
+	c := healthpb.NewHealthClient(conn)
+	if _, err := c.Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := c.Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	cancel()
+	stream.Recv() // returns the cancellation error
+
+	const check = "/grpc.health.v1.Health/Check"
+	const watch = "/grpc.health.v1.Health/Watch"
+
+	got := client.get()
+	if len(got) != 2 || got[0].method != check || got[0].err != nil || got[1].method != watch || got[1].err == nil {
+		t.Errorf("Interceptor: unexpected client results %v\n", got)
+	}
+
+	// the server notices the cancellation asynchronously
+	got = waitResults(&server, 2)
+	if len(got) != 2 || got[0].method != check || got[1].method != watch {
+		t.Errorf("Interceptor: unexpected server results %v\n", got)
+	}
+}
+
+func TestStreamClientInterceptor_ClientStreaming(t *testing.T) {
+	var server, client recorder
+	conn := serve(t, &server, &client)
+
+	stream, err := conn.NewStream(context.Background(), &sumDesc.Streams[0], "/test.Sum/Sum")
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	for _, n := range []int64{1, 2, 3} {
+		if err := stream.SendMsg(wrapperspb.Int64(n)); err != nil {
+			t.Fatalf("error: %s\n", err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	var sum wrapperspb.Int64Value
+	if err := stream.RecvMsg(&sum); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	if sum.Value != 6 {
+		t.Errorf("Sum: got: %d expected: 6\n", sum.Value)
+	}
+
+	got := client.get()
+	if len(got) != 1 || got[0].method != "/test.Sum/Sum" || got[0].err != nil {
+		t.Errorf("Interceptor: got: %v expected the client-streaming RPC after its response\n", got)
+	}
+}
+
+func TestStreamClientInterceptor_Abandoned(t *testing.T) {
+	var server, client recorder
+	conn := serve(t, &server, &client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := healthpb.NewHealthClient(conn).Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	cancel() // the stream is not received from anymore
+
+	got := waitResults(&client, 1)
+	if len(got) != 1 || got[0].method != "/grpc.health.v1.Health/Watch" || status.Code(got[0].err) != codes.Canceled {
+		t.Errorf("Interceptor: got: %v expected the cancelled stream\n", got)
+	}
+}
@@ -0,0 +1,106 @@
+package stopwatch
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// debugState is the live state of a stopwatch served by Handler.
+type debugState struct {
+	State     string         `json:"state"`
+	Elapsed   string         `json:"elapsed"`
+	ElapsedNs int64          `json:"elapsed_ns"`
+	Laps      []int64        `json:"laps_ns"`
+	Sections  []debugSection `json:"sections,omitempty"`
+}
+
+type debugSection struct {
+	Name      string         `json:"name"`
+	Open      bool           `json:"open"`
+	ElapsedNs int64          `json:"elapsed_ns"`
+	Children  []debugSection `json:"children,omitempty"`
+}
+
+// Handler returns an http.Handler that serves the live state of all
+// registered stopwatches as JSON, keyed by their names. The state, elapsed
+// time, laps and sections of each stopwatch are included. The name query
+// parameter selects a single stopwatch, such as /debug/stopwatch?name=build.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var v interface{}
+		if name := r.URL.Query().Get("name"); name != "" {
+			s := Lookup(name)
+			if s == nil {
+				http.Error(w, "stopwatch: unknown stopwatch "+name, http.StatusNotFound)
+				return
+			}
+			v = s.debugState()
+		} else {
+			v = debugStates()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(v)
+	})
+}
+
+// debugStates returns the states of all registered stopwatches.
+func debugStates() map[string]debugState {
+	states := make(map[string]debugState)
+	for _, name := range registered() {
+		if s := Lookup(name); s != nil {
+			states[name] = s.debugState()
+		}
+	}
+	return states
+}
+
+func (s *Stopwatch) debugState() debugState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elapsed := s.elapsed()
+	d := debugState{
+		State:     s.state(),
+		Elapsed:   elapsed.String(),
+		ElapsedNs: int64(elapsed),
+		Laps:      make([]int64, 0, len(s.laps)),
+		Sections:  debugSections(s.sections),
+	}
+
+	for _, lap := range s.lapRecords() {
+		d.Laps = append(d.Laps, int64(lap.Duration))
+	}
+
+	return d
+}
+
+// state returns "running", "stopped" or "reset". The lock must be held.
+func (s *Stopwatch) state() string {
+	switch {
+	case s.isReseted():
+		return "reset"
+	case s.isStopped():
+		return "stopped"
+	}
+	return "running"
+}
+
+func debugSections(sections []*Section) []debugSection {
+	if len(sections) == 0 {
+		return nil
+	}
+
+	out := make([]debugSection, len(sections))
+	for i, c := range sections {
+		out[i] = debugSection{
+			Name:      c.Name,
+			Open:      c.IsOpen(),
+			ElapsedNs: int64(c.Elapsed()),
+			Children:  debugSections(c.Children),
+		}
+	}
+	return out
+}
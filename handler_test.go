@@ -0,0 +1,70 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	Register("handler-test", sw)
+	defer Unregister("handler-test")
+
+	end := sw.Section("load")
+	sw.Section("parse")
+	c.Advance(time.Second)
+	sw.Lap()
+	c.Advance(time.Second)
+	end()
+	sw.Section("run")
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/stopwatch", nil))
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Handler: got: %s expected: %s\n", ct, "application/json")
+	}
+
+	var states map[string]debugState
+	if err := json.Unmarshal(rec.Body.Bytes(), &states); err != nil {
+		t.Fatalf("Handler: error: %s\n", err)
+	}
+
+	got, ok := states["handler-test"]
+	if !ok {
+		t.Fatalf("Handler: the registered stopwatch is missing: %s\n", rec.Body.String())
+	}
+
+	if got.State != "running" || got.ElapsedNs != int64(2*time.Second) {
+		t.Errorf("Handler: got: %s %d expected: running %d\n", got.State, got.ElapsedNs, int64(2*time.Second))
+	}
+
+	if len(got.Laps) != 1 || got.Laps[0] != int64(time.Second) {
+		t.Errorf("Handler: got: %v laps expected: [%d]\n", got.Laps, int64(time.Second))
+	}
+
+	if len(got.Sections) != 2 || got.Sections[0].Open || !got.Sections[1].Open ||
+		len(got.Sections[0].Children) != 1 || got.Sections[0].Children[0].Name != "parse" {
+		t.Errorf("Handler: unexpected sections %+v\n", got.Sections)
+	}
+
+	rec = httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/stopwatch?name=handler-test", nil))
+	var single debugState
+	if err := json.Unmarshal(rec.Body.Bytes(), &single); err != nil || single.State != "running" {
+		t.Errorf("Handler: unexpected single state %s (%v)\n", rec.Body.String(), err)
+	}
+
+	rec = httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/stopwatch?name=unknown", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Handler: got: %d expected: %d\n", rec.Code, http.StatusNotFound)
+	}
+}
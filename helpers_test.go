@@ -0,0 +1,39 @@
+package stopwatch
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/fatih/stopwatch/clocktest"
+)
+
+// newClock returns a manual clock set to a fixed date.
+func newClock() *clocktest.Clock {
+	return clocktest.NewClock(time.Date(2014, 2, 10, 0, 0, 0, 0, time.UTC))
+}
+
+// syncBuffer is a bytes.Buffer that is safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// hookCount returns the number of hooks registered with OnEvent.
+func hookCount(s *Stopwatch) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.hooks)
+}
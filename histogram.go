@@ -0,0 +1,80 @@
+package stopwatch
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// histogramWidth is the length of the longest bar written by WriteHistogram.
+const histogramWidth = 40
+
+// Histogram returns the number of stored laps in each of the given buckets.
+// Buckets are inclusive upper bounds and are sorted if needed. The returned
+// slice has one more element than buckets, counting the laps above the
+// largest bucket.
+// Example : s.Histogram([]time.Duration{10 * time.Millisecond, 100 * time.Millisecond})
+func (s *Stopwatch) Histogram(buckets []time.Duration) []int {
+	buckets = sortedBuckets(buckets)
+
+	counts := make([]int, len(buckets)+1)
+	for _, lap := range s.Laps() {
+		i := sort.Search(len(buckets), func(i int) bool { return lap <= buckets[i] })
+		counts[i]++
+	}
+	return counts
+}
+
+// WriteHistogram writes the histogram of the stored laps as text to w, one
+// line with a bar per bucket.
+// Example output:
+//
+//	<= 10ms   3  ###
+//	<= 100ms  5  #####
+//	> 100ms   1  #
+func (s *Stopwatch) WriteHistogram(w io.Writer, buckets []time.Duration) error {
+	buckets = sortedBuckets(buckets)
+	counts := s.Histogram(buckets)
+
+	max := 0
+	for _, n := range counts {
+		if n > max {
+			max = n
+		}
+	}
+
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	for i, n := range counts {
+		label := "all"
+		if i < len(buckets) {
+			label = "<= " + s.formatDuration(buckets[i])
+		} else if i > 0 {
+			label = "> " + s.formatDuration(buckets[i-1])
+		}
+
+		bar := 0
+		if max > 0 {
+			bar = (n*histogramWidth + max - 1) / max
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", label, n, strings.Repeat("#", bar))
+	}
+	tw.Flush()
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// sortedBuckets returns buckets sorted, copying them if they are not.
+func sortedBuckets(buckets []time.Duration) []time.Duration {
+	if sort.SliceIsSorted(buckets, func(i, j int) bool { return buckets[i] < buckets[j] }) {
+		return buckets
+	}
+
+	sorted := append([]time.Duration(nil), buckets...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
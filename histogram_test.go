@@ -0,0 +1,51 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_Histogram(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	for _, d := range []time.Duration{5, 10, 50, 80, 200} {
+		c.Advance(d * time.Millisecond)
+		sw.Lap()
+	}
+
+	buckets := []time.Duration{100 * time.Millisecond, 10 * time.Millisecond}
+	counts := sw.Histogram(buckets)
+	expected := []int{2, 2, 1}
+	if len(counts) != len(expected) {
+		t.Fatalf("Histogram: got: %v expected: %v\n", counts, expected)
+	}
+	for i := range expected {
+		if counts[i] != expected[i] {
+			t.Errorf("Histogram: got: %v expected: %v\n", counts, expected)
+			break
+		}
+	}
+	if buckets[0] != 100*time.Millisecond {
+		t.Error("Histogram: the given buckets should not be modified")
+	}
+
+	var buf bytes.Buffer
+	if err := sw.WriteHistogram(&buf, buckets); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	out := "<= 10ms   2  ########################################\n" +
+		"<= 100ms  2  ########################################\n" +
+		"> 100ms   1  ####################\n"
+	if buf.String() != out {
+		t.Errorf("WriteHistogram: got:\n%s\nexpected:\n%s\n", buf.String(), out)
+	}
+
+	if counts := sw.Histogram(nil); len(counts) != 1 || counts[0] != 5 {
+		t.Errorf("Histogram: got: %v expected: [5]\n", counts)
+	}
+}
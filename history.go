@@ -0,0 +1,38 @@
+package stopwatch
+
+// WithHistory keeps the latest n sessions of the stopwatch, each Reset and
+// Restart adds the session it ends, see Sessions. This keeps the history of
+// a stopwatch reused for repeated runs of the same task.
+func WithHistory(n int) Option {
+	return func(s *Stopwatch) { s.maxHistory = n }
+}
+
+// Sessions returns the completed sessions kept with WithHistory, the oldest
+// first. The current session is not included.
+func (s *Stopwatch) Sessions() []Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Session(nil), s.history...)
+}
+
+// keepSession adds the current session to the history if it is enabled. The
+// lock must be held.
+func (s *Stopwatch) keepSession() {
+	if s.maxHistory <= 0 || s.isReseted() {
+		return
+	}
+
+	session := Session{Start: s.start, End: s.now(), Elapsed: s.elapsed(), Laps: s.lapRecords()}
+	if len(s.runs) > 0 {
+		session.Start = s.runs[0].from
+	}
+	if s.isStopped() {
+		session.End = s.stop
+	}
+
+	if len(s.history) >= s.maxHistory {
+		n := copy(s.history, s.history[len(s.history)-s.maxHistory+1:])
+		s.history = s.history[:n]
+	}
+	s.history = append(s.history, session)
+}
@@ -0,0 +1,52 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStopwatch_Sessions(t *testing.T) {
+	c := newClock()
+	start := c.Now()
+	sw := Start(0, WithClock(c), WithHistory(2))
+
+	c.Advance(time.Second)
+	sw.Lap()
+	c.Advance(time.Second)
+	sw.Stop()
+	sw.Reset()
+	sw.Reset()
+
+	sessions := sw.Sessions()
+	if len(sessions) != 1 {
+		t.Fatalf("Reset: got: %d sessions expected: 1\n", len(sessions))
+	}
+	if got := sessions[0]; !got.Start.Equal(start) || !got.End.Equal(start.Add(2*time.Second)) ||
+		got.Elapsed != 2*time.Second || len(got.Laps) != 1 {
+		t.Errorf("Reset: got: %+v expected: 2s session with 1 lap\n", got)
+	}
+
+	sw.Start(0)
+	c.Advance(3 * time.Second)
+	sw.Restart()
+	c.Advance(4 * time.Second)
+	sw.Restart()
+
+	sessions = sw.Sessions()
+	if len(sessions) != 2 {
+		t.Fatalf("Restart: got: %d sessions expected: 2\n", len(sessions))
+	}
+	if sessions[0].Elapsed != 3*time.Second || sessions[1].Elapsed != 4*time.Second {
+		t.Errorf("Restart: got: %s %s expected: 3s 4s\n", sessions[0].Elapsed, sessions[1].Elapsed)
+	}
+	if len(sessions[1].Laps) != 0 {
+		t.Errorf("Restart: got laps: %v expected: none\n", sessions[1].Laps)
+	}
+
+	if got := New().Sessions(); got != nil {
+		t.Errorf("Sessions: got: %v expected: none without WithHistory\n", got)
+	}
+}
@@ -0,0 +1,99 @@
+package stopwatch
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// jsonlEvent is a single line written by WriteJSONL.
+type jsonlEvent struct {
+	Seq      uint64            `json:"seq"`
+	Kind     string            `json:"kind"`
+	Time     time.Time         `json:"time"`
+	Elapsed  time.Duration     `json:"elapsed_ns"`
+	Duration time.Duration     `json:"duration_ns,omitempty"`
+	Section  string            `json:"section,omitempty"`
+	Tags     map[string]string `json:"tags,omitempty"`
+}
+
+// WriteJSONL writes every following event of the stopwatch to w, one JSON
+// object per line, such as the laps of a long running session. The returned
+// function stops writing and returns the first write error.
+func (s *Stopwatch) WriteJSONL(w io.Writer) (stop func() error) {
+	var (
+		mu      sync.Mutex
+		stopped bool
+		werr    error
+	)
+
+	remove := s.OnEvent(func(e Event) {
+		line, err := json.Marshal(jsonlEvent{
+			Seq:      e.Seq,
+			Kind:     e.Kind.String(),
+			Time:     e.Time,
+			Elapsed:  e.Elapsed,
+			Duration: e.Duration,
+			Section:  e.Section,
+			Tags:     e.Tags,
+		})
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if stopped || werr != nil {
+			return
+		}
+		if err == nil {
+			_, err = w.Write(append(line, '\n'))
+		}
+		werr = err
+	})
+
+	return func() error {
+		remove()
+
+		mu.Lock()
+		defer mu.Unlock()
+		stopped = true
+		return werr
+	}
+}
+
+// WriteJSONLFile is like WriteJSONL, but writes to the file at path. The file
+// is compressed if its extension belongs to a registered Compressor, such as
+// "laps.jsonl.gz". The returned function also closes the file.
+func (s *Stopwatch) WriteJSONLFile(path string) (stop func() error, err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		w  io.Writer = f
+		cw io.WriteCloser
+	)
+	if c, _ := compressorFor(path); c != nil {
+		if cw, err = c.NewWriter(f); err != nil {
+			f.Close()
+			return nil, err
+		}
+		w = cw
+	}
+
+	stopWriting := s.WriteJSONL(w)
+	return func() error {
+		err := stopWriting()
+		if cw != nil {
+			if cerr := cw.Close(); err == nil {
+				err = cerr
+			}
+		}
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		return err
+	}, nil
+}
@@ -0,0 +1,104 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_WriteJSONL(t *testing.T) {
+	c := newClock()
+	sw := New(WithClock(c))
+
+	var buf bytes.Buffer
+	stop := sw.WriteJSONL(&buf)
+
+	sw.Start(0)
+	c.Advance(time.Second)
+	sw.Lap()
+	sw.Stop()
+
+	if err := stop(); err != nil {
+		t.Fatalf("WriteJSONL: error: %s\n", err)
+	}
+	sw.Reset() // not written anymore
+
+	var kinds []string
+	var lap time.Duration
+	sc := bufio.NewScanner(&buf)
+	for sc.Scan() {
+		var e jsonlEvent
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			t.Fatalf("WriteJSONL: the line %q is not JSON: %s\n", sc.Text(), err)
+		}
+		kinds = append(kinds, e.Kind)
+		if e.Kind == "lap" {
+			lap = e.Duration
+		}
+	}
+
+	if got := len(kinds); got != 3 || kinds[0] != "start" || kinds[1] != "lap" || kinds[2] != "stop" {
+		t.Errorf("WriteJSONL: expected start, lap and stop, got %v\n", kinds)
+	}
+
+	if lap != time.Second {
+		t.Errorf("WriteJSONL: expected a lap of %s, got %s\n", time.Second, lap)
+	}
+
+	if got := hookCount(sw); got != 0 {
+		t.Errorf("WriteJSONL: expected the hook to be removed, got %d hooks\n", got)
+	}
+}
+
+func TestStopwatch_WriteJSONLFileCompressed(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+
+	path := filepath.Join(t.TempDir(), "laps.jsonl.gz")
+	stop, err := sw.WriteJSONLFile(path)
+	if err != nil {
+		t.Fatalf("WriteJSONLFile: error: %s\n", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		c.Advance(time.Second)
+		sw.Lap()
+	}
+
+	if err := stop(); err != nil {
+		t.Fatalf("WriteJSONLFile: error: %s\n", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("WriteJSONLFile: error: %s\n", err)
+	}
+	defer f.Close()
+
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("WriteJSONLFile: the file is not gzipped: %s\n", err)
+	}
+
+	lines := 0
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		lines++
+	}
+
+	if err := sc.Err(); err != nil {
+		t.Fatalf("WriteJSONLFile: error: %s\n", err)
+	}
+
+	if lines != 3 {
+		t.Errorf("WriteJSONLFile: expected 3 lines, got %d\n", lines)
+	}
+}
@@ -0,0 +1,40 @@
+package stopwatch
+
+import "time"
+
+// LabelTag is the tag holding the label of a lap, see LapWithLabel.
+const LabelTag = "label"
+
+// LapWithLabel takes a lap like Lap and labels it with the operation it
+// timed, such as "db" or "render". The label is the LabelTag tag of the lap.
+// Example : s.LapWithLabel("db")
+func (s *Stopwatch) LapWithLabel(label string) time.Duration {
+	return s.LapWithTags(map[string]string{LabelTag: label})
+}
+
+// AggregateByLabel returns the statistics of the stored laps by their
+// label, see LapWithLabel. Laps without a label are left out.
+func (s *Stopwatch) AggregateByLabel() map[string]LapStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := make(map[string]LapStats)
+	for _, lap := range s.laps {
+		label, ok := lap.Tags[LabelTag]
+		if !ok {
+			continue
+		}
+
+		st := stats[label]
+		if st.Count == 0 || lap.Duration < st.Min {
+			st.Min = lap.Duration
+		}
+		if lap.Duration > st.Max {
+			st.Max = lap.Duration
+		}
+		st.Count++
+		st.Total += lap.Duration
+		stats[label] = st
+	}
+	return stats
+}
@@ -0,0 +1,45 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStopwatch_AggregateByLabel(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+
+	for _, lap := range []struct {
+		label string
+		d     time.Duration
+	}{
+		{"db", 10 * time.Millisecond},
+		{"render", 5 * time.Millisecond},
+		{"db", 30 * time.Millisecond},
+		{"", time.Millisecond},
+	} {
+		c.Advance(lap.d)
+		if lap.label == "" {
+			sw.Lap()
+		} else {
+			sw.LapWithLabel(lap.label)
+		}
+	}
+
+	stats := sw.AggregateByLabel()
+	if len(stats) != 2 {
+		t.Fatalf("AggregateByLabel: got: %v expected: db and render\n", stats)
+	}
+
+	db := stats["db"]
+	if db.Count != 2 || db.Total != 40*time.Millisecond || db.Min != 10*time.Millisecond ||
+		db.Max != 30*time.Millisecond || db.Mean() != 20*time.Millisecond {
+		t.Errorf("AggregateByLabel: got db: %+v expected: 2 laps of 40ms\n", db)
+	}
+	if render := stats["render"]; render.Count != 1 || render.Total != 5*time.Millisecond {
+		t.Errorf("AggregateByLabel: got render: %+v expected: 1 lap of 5ms\n", render)
+	}
+}
@@ -0,0 +1,169 @@
+package stopwatch
+
+import "time"
+
+// LapStats are aggregate statistics of all laps of a session, including the
+// laps discarded because of WithMaxLaps.
+type LapStats struct {
+	Count    int
+	Total    time.Duration
+	Min, Max time.Duration
+}
+
+// Mean returns the average lap duration, or zero if there are no laps.
+func (l LapStats) Mean() time.Duration {
+	if l.Count == 0 {
+		return 0
+	}
+	return l.Total / time.Duration(l.Count)
+}
+
+// WithMaxLaps keeps only the latest n laps in a ring buffer, older laps are
+// discarded. LapStats still covers all laps of the session. This bounds the
+// memory of long running stopwatches that take a lap per request. A value of
+// zero or less keeps all laps, which is the default.
+func WithMaxLaps(n int) Option {
+	return func(s *Stopwatch) { s.maxLaps = n }
+}
+
+// LapStats returns the aggregate statistics of all laps of the session.
+func (s *Stopwatch) LapStats() LapStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lapStats
+}
+
+// LapTimes returns the wall clock times at which the stored laps were taken,
+// in the same order as Laps.
+func (s *Stopwatch) LapTimes() []time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	times := make([]time.Time, 0, len(s.laps))
+	for _, lap := range s.lapRecords() {
+		times = append(times, lap.Time)
+	}
+	return times
+}
+
+// Splits returns the elapsed times of the stopwatch at the stored laps, the
+// cumulative counterpart of Laps.
+func (s *Stopwatch) Splits() []time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	splits := make([]time.Duration, 0, len(s.laps))
+	for _, lap := range s.lapRecords() {
+		splits = append(splits, lap.Split)
+	}
+	return splits
+}
+
+// FastestLap returns the shortest stored lap and its index in Laps. The index
+// is -1 if there are no laps. Of equal laps the first one is returned.
+func (s *Stopwatch) FastestLap() (time.Duration, int) {
+	return s.findLap(func(a, b time.Duration) bool { return a < b })
+}
+
+// SlowestLap returns the longest stored lap and its index in Laps. The index
+// is -1 if there are no laps. Of equal laps the first one is returned.
+func (s *Stopwatch) SlowestLap() (time.Duration, int) {
+	return s.findLap(func(a, b time.Duration) bool { return a > b })
+}
+
+// AverageLap returns the average duration of the stored laps, or zero if
+// there are no laps. See LapStats for the average of all laps of a session
+// that discards laps with WithMaxLaps.
+func (s *Stopwatch) AverageLap() time.Duration {
+	laps := s.Laps()
+	if len(laps) == 0 {
+		return 0
+	}
+
+	var total time.Duration
+	for _, lap := range laps {
+		total += lap
+	}
+	return total / time.Duration(len(laps))
+}
+
+// findLap returns the stored lap for which better reports true against all
+// other laps, and its index.
+func (s *Stopwatch) findLap(better func(a, b time.Duration) bool) (time.Duration, int) {
+	index := -1
+	var found time.Duration
+	for i, lap := range s.Laps() {
+		if index < 0 || better(lap, found) {
+			found, index = lap, i
+		}
+	}
+	return found, index
+}
+
+// ClearLaps discards all laps and their statistics. Unlike Reset the session
+// goes on, the elapsed time is kept and the next lap is measured from the
+// latest lap taken.
+func (s *Stopwatch) ClearLaps() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	base := s.lapBase + s.lapStats.Total
+	s.resetLaps()
+	s.lapBase = base
+}
+
+// addLap stores r, overwriting the oldest lap if the ring buffer is full. The
+// lock must be held.
+func (s *Stopwatch) addLap(r LapRecord) {
+	s.updateEWMA(r.Duration)
+	if s.lapStats.Count == 0 || r.Duration < s.lapStats.Min {
+		s.lapStats.Min = r.Duration
+	}
+	if s.lapStats.Count == 0 || r.Duration > s.lapStats.Max {
+		s.lapStats.Max = r.Duration
+	}
+	s.lapStats.Count++
+	s.lapStats.Total += r.Duration
+
+	if s.maxLaps <= 0 || len(s.laps) < s.maxLaps {
+		s.laps = append(s.laps, r)
+		return
+	}
+
+	s.laps[s.lapHead] = r
+	s.lapHead = (s.lapHead + 1) % len(s.laps)
+}
+
+// lapRecords returns a copy of the stored laps, oldest first. The lock must
+// be held.
+func (s *Stopwatch) lapRecords() []LapRecord {
+	laps := make([]LapRecord, 0, len(s.laps))
+	laps = append(laps, s.laps[s.lapHead:]...)
+	return append(laps, s.laps[:s.lapHead]...)
+}
+
+// lapOffset returns the running time at which the first stored lap started.
+// It is non-zero if older laps were discarded or cleared. The lock must be
+// held.
+func (s *Stopwatch) lapOffset() time.Duration {
+	offset := s.lapBase + s.lapStats.Total
+	for _, lap := range s.laps {
+		offset -= lap.Duration
+	}
+	return offset
+}
+
+// resetLaps discards all laps and their statistics, the storage of the laps
+// is reused. The lock must be held.
+func (s *Stopwatch) resetLaps() {
+	for i := range s.laps {
+		s.laps[i] = LapRecord{}
+	}
+	s.laps = s.laps[:0]
+	s.lapHead = 0
+	s.lapStats = LapStats{}
+	s.lapBase = 0
+	for alpha := range s.ewma {
+		s.ewma[alpha] = 0
+	}
+}
@@ -0,0 +1,41 @@
+//go:build go1.23
+// +build go1.23
+
+package stopwatch
+
+import "iter"
+
+// AllLaps returns an iterator over the laps of the session and their lap
+// numbers, oldest first. Unlike LapRecords the laps are read one at a time
+// from the stopwatch without copying all of them, laps taken while iterating
+// are yielded too. With WithMaxLaps the lap numbers count the discarded laps
+// as well, and laps discarded while iterating are skipped.
+// Example : for i, lap := range s.AllLaps() { ... }
+func (s *Stopwatch) AllLaps() iter.Seq2[int, LapRecord] {
+	return func(yield func(int, LapRecord) bool) {
+		for n := 0; ; n++ {
+			lap, next, ok := s.lapAt(n)
+			if !ok || !yield(next, lap) {
+				return
+			}
+			n = next
+		}
+	}
+}
+
+// lapAt returns the lap with the lap number n, or the oldest stored lap after
+// it if it was discarded, and its lap number.
+func (s *Stopwatch) lapAt(n int) (LapRecord, int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	first := s.lapStats.Count - len(s.laps)
+	if n < first {
+		n = first
+	}
+	if n >= s.lapStats.Count {
+		return LapRecord{}, 0, false
+	}
+
+	return s.laps[(s.lapHead+n-first)%len(s.laps)], n, true
+}
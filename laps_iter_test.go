@@ -0,0 +1,53 @@
+//go:build go1.23 && !stopwatch_off
+// +build go1.23,!stopwatch_off
+
+package stopwatch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStopwatch_AllLaps(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c), WithMaxLaps(3))
+	for i := 1; i <= 5; i++ {
+		c.Advance(time.Duration(i) * time.Second)
+		sw.Lap()
+	}
+
+	var numbers []int
+	var laps []time.Duration
+	for i, lap := range sw.AllLaps() {
+		numbers = append(numbers, i)
+		laps = append(laps, lap.Duration)
+	}
+
+	expected := []time.Duration{3 * time.Second, 4 * time.Second, 5 * time.Second}
+	if len(laps) != len(expected) {
+		t.Fatalf("AllLaps: got: %v expected: %v\n", laps, expected)
+	}
+	for i := range expected {
+		if laps[i] != expected[i] || numbers[i] != i+2 {
+			t.Errorf("AllLaps: lap %d got: %d %s expected: %d %s\n", i, numbers[i], laps[i], i+2, expected[i])
+		}
+	}
+
+	// laps taken while iterating are yielded, breaking stops the iteration
+	count := 0
+	for range sw.AllLaps() {
+		count++
+		if count < 5 {
+			sw.Lap()
+		} else {
+			break
+		}
+	}
+	if count != 5 {
+		t.Errorf("AllLaps: got: %d laps expected: 5\n", count)
+	}
+
+	for range New().AllLaps() {
+		t.Error("AllLaps: a reseted stopwatch should have no laps")
+	}
+}
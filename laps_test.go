@@ -0,0 +1,203 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_WithMaxLaps(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c), WithMaxLaps(3))
+
+	for i := 1; i <= 5; i++ {
+		c.Advance(time.Duration(i) * time.Second)
+		sw.Lap()
+	}
+
+	expected := []time.Duration{3 * time.Second, 4 * time.Second, 5 * time.Second}
+	if laps := sw.Laps(); !reflect.DeepEqual(laps, expected) {
+		t.Errorf("WithMaxLaps: got: %v expected: %v\n", laps, expected)
+	}
+
+	if records := sw.LapRecords(); records[0].Seq != 3 || records[2].Seq != 5 {
+		t.Errorf("WithMaxLaps: got: %v expected the sequence numbers 3 to 5\n", records)
+	}
+
+	stats := sw.LapStats()
+	if stats.Count != 5 || stats.Total != 15*time.Second || stats.Min != time.Second ||
+		stats.Max != 5*time.Second || stats.Mean() != 3*time.Second {
+		t.Errorf("LapStats: got: %+v expected the statistics of all 5 laps\n", stats)
+	}
+
+	if offset := sw.lapOffset(); offset != 3*time.Second {
+		t.Errorf("lapOffset: got: %s expected: 3s\n", offset)
+	}
+
+	sw.Reset()
+	if stats := sw.LapStats(); stats != (LapStats{}) || len(sw.Laps()) != 0 {
+		t.Errorf("Reset: got: %+v and %v expected no laps\n", stats, sw.Laps())
+	}
+}
+
+func TestStopwatch_LapTimes(t *testing.T) {
+	c := newClock()
+	start := c.Now()
+	sw := Start(0, WithClock(c))
+
+	c.Advance(time.Second)
+	sw.Lap()
+
+	sw.Stop()
+	c.Advance(time.Minute)
+	sw.Start(0)
+
+	c.Advance(time.Second)
+	sw.Lap()
+
+	expected := []time.Time{start.Add(time.Second), start.Add(time.Minute + 2*time.Second)}
+	if times := sw.LapTimes(); !reflect.DeepEqual(times, expected) {
+		t.Errorf("LapTimes: got: %v expected: %v\n", times, expected)
+	}
+
+	if records := sw.LapRecords(); !records[1].Time.Equal(expected[1]) {
+		t.Errorf("LapRecords: got: %+v expected the lap time %s\n", records[1], expected[1])
+	}
+}
+
+func TestStopwatch_ClearLaps(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+
+	c.Advance(time.Second)
+	sw.Lap()
+	c.Advance(2 * time.Second)
+	sw.Lap()
+
+	sw.ClearLaps()
+	if len(sw.Laps()) != 0 || sw.LapStats().Count != 0 {
+		t.Errorf("ClearLaps: got: %v expected no laps\n", sw.Laps())
+	}
+	if sw.IsStopped() || sw.ElapsedTime() != 3*time.Second {
+		t.Errorf("ClearLaps: got: %s expected a running stopwatch at 3s\n", sw.ElapsedTime())
+	}
+
+	c.Advance(time.Second)
+	if lap := sw.Lap(); lap != time.Second {
+		t.Errorf("Lap: got: %s expected: 1s\n", lap)
+	}
+	if offset := sw.lapOffset(); offset != 3*time.Second {
+		t.Errorf("lapOffset: got: %s expected: 3s\n", offset)
+	}
+}
+
+func TestStopwatch_FastestSlowestAverageLap(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+
+	if _, i := sw.FastestLap(); i != -1 {
+		t.Errorf("FastestLap: got index: %d expected: -1\n", i)
+	}
+	if avg := sw.AverageLap(); avg != 0 {
+		t.Errorf("AverageLap: got: %s expected: 0\n", avg)
+	}
+
+	for _, d := range []time.Duration{3, 1, 5, 1, 5} {
+		c.Advance(d * time.Second)
+		sw.Lap()
+	}
+
+	if lap, i := sw.FastestLap(); lap != time.Second || i != 1 {
+		t.Errorf("FastestLap: got: %s at %d expected: 1s at 1\n", lap, i)
+	}
+	if lap, i := sw.SlowestLap(); lap != 5*time.Second || i != 2 {
+		t.Errorf("SlowestLap: got: %s at %d expected: 5s at 2\n", lap, i)
+	}
+	if avg := sw.AverageLap(); avg != 3*time.Second {
+		t.Errorf("AverageLap: got: %s expected: 3s\n", avg)
+	}
+}
+
+func TestStopwatch_Split(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+
+	c.Advance(time.Second)
+	if split := sw.Split(); split != time.Second {
+		t.Errorf("Split: got: %s expected: 1s\n", split)
+	}
+
+	c.Advance(2 * time.Second)
+	sw.Lap()
+
+	c.Advance(3 * time.Second)
+	if split := sw.Split(); split != 6*time.Second {
+		t.Errorf("Split: got: %s expected: 6s\n", split)
+	}
+
+	expected := []time.Duration{time.Second, 3 * time.Second, 6 * time.Second}
+	if splits := sw.Splits(); !reflect.DeepEqual(splits, expected) {
+		t.Errorf("Splits: got: %v expected: %v\n", splits, expected)
+	}
+
+	sw.Stop()
+	if split := sw.Split(); split != 0 {
+		t.Errorf("Split: got: %s expected: 0 for a stopped stopwatch\n", split)
+	}
+}
+
+func TestStopwatch_SinceLap(t *testing.T) {
+	c := newClock()
+	sw := New(WithClock(c))
+
+	if d := sw.SinceLap(); d != 0 {
+		t.Errorf("SinceLap: got: %s expected: 0 for a reseted stopwatch\n", d)
+	}
+
+	sw.Start(0)
+	c.Advance(time.Second)
+	if d := sw.SinceLap(); d != time.Second {
+		t.Errorf("SinceLap: got: %s expected: 1s\n", d)
+	}
+
+	sw.Lap()
+	c.Advance(2 * time.Second)
+	if d := sw.SinceLap(); d != 2*time.Second || len(sw.Laps()) != 1 {
+		t.Errorf("SinceLap: got: %s and %d laps expected: 2s and a single lap\n", d, len(sw.Laps()))
+	}
+
+	sw.Stop()
+	c.Advance(time.Minute)
+	if d := sw.SinceLap(); d != 2*time.Second {
+		t.Errorf("SinceLap: got: %s expected: 2s for a stopped stopwatch\n", d)
+	}
+}
+
+func TestStopwatch_ReportMaxLaps(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c), WithMaxLaps(2))
+	for i := 1; i <= 3; i++ {
+		c.Advance(time.Duration(i) * time.Second)
+		sw.Lap()
+	}
+
+	var md, text bytes.Buffer
+	if err := sw.Report(&md, ReportMarkdown); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	if err := sw.Report(&text, ReportText); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	if !strings.Contains(md.String(), "| 2 | 2s |\n| 3 | 3s |") {
+		t.Errorf("Report: got:\n%s\nexpected laps 2 and 3\n", md.String())
+	}
+	if !strings.Contains(text.String(), "2    2s\n3    3s") {
+		t.Errorf("Report: got:\n%s\nexpected laps 2 and 3\n", text.String())
+	}
+}
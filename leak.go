@@ -0,0 +1,48 @@
+package stopwatch
+
+import (
+	"log"
+	"runtime"
+	"time"
+)
+
+// Leak is a stopwatch that was garbage collected while it was running, see
+// WithLeakDetection.
+type Leak struct {
+	Caller  string        // file:line at which the stopwatch was created
+	Elapsed time.Duration // elapsed time when it was collected
+}
+
+func (l Leak) String() string {
+	return "stopwatch: created at " + l.Caller + " was never stopped, elapsed: " + l.Elapsed.String()
+}
+
+// WithLeakDetection reports the stopwatch to fn if it is garbage collected
+// while it is still running, together with the call site that created it. A
+// nil fn logs the leak with log.Print instead. This is meant for debugging,
+// such as to find forgotten instrumentation, as it costs a stack walk per
+// stopwatch and a finalizer. fn is called from the finalizer goroutine.
+// Example : stopwatch.Start(0, stopwatch.WithLeakDetection(nil))
+func WithLeakDetection(fn func(Leak)) Option {
+	return func(s *Stopwatch) {
+		if fn == nil {
+			fn = func(l Leak) { log.Print(l) }
+		}
+		s.onLeak = fn
+	}
+}
+
+// watchLeak records the call site creating s and registers the finalizer
+// reporting it if it leaks.
+func watchLeak(s *Stopwatch) {
+	caller := callSite()
+	runtime.SetFinalizer(s, func(s *Stopwatch) {
+		s.mu.Lock()
+		running, elapsed := s.isRunning(), s.elapsed()
+		s.mu.Unlock()
+
+		if running {
+			s.onLeak(Leak{Caller: caller, Elapsed: elapsed})
+		}
+	})
+}
@@ -0,0 +1,42 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_LeakDetection(t *testing.T) {
+	leaks := make(chan Leak, 2)
+	func() {
+		Start(0, WithLeakDetection(func(l Leak) { leaks <- l }))
+		Start(0, WithLeakDetection(func(l Leak) { leaks <- l })).Stop()
+	}()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case l := <-leaks:
+			if !strings.Contains(l.Caller, "leak_test.go:") {
+				t.Errorf("WithLeakDetection: got caller: %s expected: leak_test.go\n", l.Caller)
+			}
+
+			// the stopped stopwatch is not reported
+			runtime.GC()
+			select {
+			case l := <-leaks:
+				t.Errorf("WithLeakDetection: got a second leak: %s\n", l)
+			case <-time.After(50 * time.Millisecond):
+			}
+			return
+		case <-deadline:
+			t.Fatal("WithLeakDetection: the leak was not reported")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package stopwatch
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	lpMeasurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	lpTagEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+)
+
+// ExportLineProtocol writes the laps and the total of the session in the
+// InfluxDB line protocol, tagged with tags and the tags of the stopwatch. The
+// tags of a lap win over both, like in its EventLap. The "kind" tag tells laps
+// and sessions apart, so a tag named "kind" is written as "tag_kind". Tags
+// with empty values are left out, the line protocol has no empty values.
+// Example : build,host=a,kind=lap lap=0i,seq=1i,duration_ns=1500000i 1392000000000000000
+func (s *Stopwatch) ExportLineProtocol(w io.Writer, measurement string, tags map[string]string) error {
+	anchor, err := s.anchorOffset()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	if s.isReseted() {
+		s.mu.Unlock()
+		return nil
+	}
+
+	start, elapsed := s.start.Add(anchor), s.elapsed()
+	laps, offset, first := s.lapRecords(), s.lapOffset(), s.lapStats.Count-len(s.laps)
+	tags = mergeTags(s.tags, tags)
+	s.mu.Unlock()
+
+	var b strings.Builder
+	line := func(kind string, extra map[string]string, fields string, t time.Time) {
+		all := mergeTags(tags, extra)
+		if v, ok := all["kind"]; ok {
+			delete(all, "kind")
+			if _, ok := all["tag_kind"]; !ok {
+				all["tag_kind"] = v
+			}
+		}
+
+		keys := make([]string, 0, len(all))
+		for k, v := range all {
+			if v != "" {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		b.WriteString(lpMeasurementEscaper.Replace(measurement))
+		for _, k := range keys {
+			b.WriteString("," + lpTagEscaper.Replace(k) + "=" + lpTagEscaper.Replace(all[k]))
+		}
+		b.WriteString(",kind=" + kind + " " + fields + " " + strconv.FormatInt(t.UnixNano(), 10) + "\n")
+	}
+
+	for i, lap := range laps {
+		offset += lap.Duration
+		t := start.Add(offset)
+		if !lap.Time.IsZero() {
+			t = lap.Time.Add(anchor)
+		}
+		line("lap", lap.Tags, "lap="+strconv.Itoa(first+i)+"i,seq="+strconv.FormatUint(lap.Seq, 10)+
+			"i,duration_ns="+strconv.FormatInt(int64(lap.Duration), 10)+"i", t)
+	}
+
+	line("session", nil, "elapsed_ns="+strconv.FormatInt(int64(elapsed), 10)+
+		"i,laps="+strconv.Itoa(first+len(laps))+"i", start.Add(elapsed))
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
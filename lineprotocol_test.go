@@ -0,0 +1,66 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_ExportLineProtocol(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	start := c.Now().UnixNano()
+
+	c.Advance(time.Second)
+	sw.Lap()
+	c.Advance(time.Second)
+	sw.Stop()
+
+	var buf bytes.Buffer
+	err := sw.ExportLineProtocol(&buf, "my build", map[string]string{"host": "a,b", "kind": "x"})
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	expected := []string{
+		`my\ build,host=a\,b,tag_kind=x,kind=lap lap=0i,seq=1i,duration_ns=1000000000i ` + itoa(start+int64(time.Second)),
+		`my\ build,host=a\,b,tag_kind=x,kind=session elapsed_ns=2000000000i,laps=1i ` + itoa(start+int64(2*time.Second)),
+	}
+
+	got := strings.TrimSpace(buf.String())
+	if got != strings.Join(expected, "\n") {
+		t.Errorf("ExportLineProtocol: got:\n%s\nexpected:\n%s\n", got, strings.Join(expected, "\n"))
+	}
+
+	buf.Reset()
+	New().ExportLineProtocol(&buf, "m", nil)
+	if buf.Len() != 0 {
+		t.Errorf("ExportLineProtocol: a reseted stopwatch wrote %q\n", buf.String())
+	}
+}
+
+func TestStopwatch_ExportLineProtocolLapTags(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	sw.SetTag("stage", "all")
+	c.Advance(time.Second)
+	sw.LapWithTags(map[string]string{"stage": "parse"})
+	sw.Stop()
+
+	var buf bytes.Buffer
+	if err := sw.ExportLineProtocol(&buf, "m", map[string]string{"host": ""}); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "m,stage=parse,kind=lap ") || !strings.HasPrefix(lines[1], "m,stage=all,kind=session ") {
+		t.Errorf("ExportLineProtocol: got:\n%s\nexpected the lap tag to win and no empty host tag\n", buf.String())
+	}
+}
+
+func itoa(i int64) string { return strconv.FormatInt(i, 10) }
@@ -0,0 +1,98 @@
+package stopwatch
+
+import (
+	"log"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// LogEvery logs msg with the elapsed time and the time of the current lap
+// every interval, as a heartbeat of a long running job. The rate is logged as
+// well once items are recorded with Tick. It logs with the logger of the
+// stopwatch like Log, which receives the lap and the rate as the "lap" and
+// "rate" tags. Logging ends once the stopwatch is stopped or reseted. The
+// returned function ends it as well and waits until it is done, it can be
+// called more than once. The event hook ending it is removed once it is done.
+// LogEvery panics if interval is not positive, like time.NewTicker.
+// Example : defer s.LogEvery(time.Minute, "import")()
+func (s *Stopwatch) LogEvery(interval time.Duration, msg string) (stop func()) {
+	if interval <= 0 {
+		panic("stopwatch: non-positive interval for LogEvery")
+	}
+
+	done := make(chan struct{})
+	var once sync.Once
+	end := func() { once.Do(func() { close(done) }) }
+
+	remove := s.OnEvent(func(e Event) {
+		if e.Kind == EventStop || e.Kind == EventReset {
+			end()
+		}
+	})
+
+	finished := make(chan struct{})
+	stop = func() {
+		end()
+		<-finished
+	}
+	untrack := s.addTask(stop)
+
+	go func() {
+		defer close(finished)
+		defer untrack()
+		defer remove()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if !s.heartbeat(msg) {
+					return
+				}
+			}
+		}
+	}()
+
+	return stop
+}
+
+// heartbeat logs a single line of LogEvery and reports whether the stopwatch
+// is running.
+func (s *Stopwatch) heartbeat(msg string) bool {
+	s.mu.Lock()
+	running := s.isRunning()
+	elapsed, lap := s.elapsed(), s.since(s.lap)
+	var rate float64
+	if s.ticks > 0 && elapsed > 0 {
+		rate = float64(s.ticks) / elapsed.Seconds()
+	}
+	tags := mergeTags(s.tags, nil)
+	s.mu.Unlock()
+
+	if !running {
+		return false
+	}
+
+	l := s.loggerOrDefault()
+	if l == nil {
+		format := s.colorFormat(log.Writer())
+		if rate > 0 {
+			log.Printf("%s - elapsed: %s lap: %s rate: %.2f/s\n", msg, format(elapsed), format(lap), rate)
+		} else {
+			log.Printf("%s - elapsed: %s lap: %s\n", msg, format(elapsed), format(lap))
+		}
+		return true
+	}
+
+	extra := map[string]string{"lap": lap.String()}
+	if rate > 0 {
+		extra["rate"] = strconv.FormatFloat(rate, 'f', 2, 64)
+	}
+	l.LogElapsed(msg, elapsed, mergeTags(tags, extra))
+	return true
+}
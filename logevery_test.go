@@ -0,0 +1,69 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_LogEvery(t *testing.T) {
+	c := newClock()
+
+	var mu sync.Mutex
+	var lines []map[string]string
+	logged := make(chan struct{}, 1)
+	sw := Start(0, WithClock(c), WithLogger(LoggerFunc(func(msg string, elapsed time.Duration, tags map[string]string) {
+		mu.Lock()
+		lines = append(lines, tags)
+		mu.Unlock()
+		select {
+		case logged <- struct{}{}:
+		default:
+		}
+	})))
+
+	c.Advance(2 * time.Second)
+	sw.Tick(10)
+	stop := sw.LogEvery(time.Millisecond, "import")
+	defer stop()
+
+	select {
+	case <-logged:
+	case <-time.After(time.Second):
+		t.Fatal("LogEvery: nothing was logged")
+	}
+
+	sw.Stop()
+	stop()
+
+	mu.Lock()
+	first := lines[0]
+	mu.Unlock()
+	if first["lap"] != "2s" || first["rate"] != "5.00" {
+		t.Errorf("LogEvery: got tags: %v expected: lap=2s rate=5.00\n", first)
+	}
+
+	if n := hookCount(sw); n != 0 {
+		t.Errorf("LogEvery: got: %d hooks after stop expected: 0\n", n)
+	}
+
+	sw.Start(0)
+	sw.LogEvery(time.Hour, "import")()
+	if n := hookCount(sw); n != 0 {
+		t.Errorf("LogEvery: got: %d hooks after stop expected: 0\n", n)
+	}
+}
+
+func TestStopwatch_LogEveryInterval(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("LogEvery: a zero interval should panic")
+		}
+	}()
+
+	sw := Start(0)
+	sw.LogEvery(0, "import")
+}
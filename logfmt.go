@@ -0,0 +1,73 @@
+package stopwatch
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Logfmt writes a single logfmt line with the time, msg, the elapsed time,
+// the number of laps and the tags of the stopwatch to w, followed by the
+// given key/value pairs. Keys are formatted with fmt.Sprint, a missing value
+// of the last key is written as an empty string.
+// Example : s.Logfmt(os.Stderr, "sync", "users", 42)
+// Output  : ts=2014-02-10T00:44:56Z msg=sync elapsed=2.001s laps=3 users=42
+func (s *Stopwatch) Logfmt(w io.Writer, msg string, kvs ...interface{}) error {
+	s.mu.Lock()
+	now, elapsed, laps := s.now(), s.elapsed(), s.lapStats.Count
+	tags := mergeTags(s.tags, nil)
+	s.mu.Unlock()
+
+	var b strings.Builder
+	b.WriteString("ts=" + now.Format(time.RFC3339Nano))
+	b.WriteString(" msg=" + logfmtValue(msg))
+	b.WriteString(" elapsed=" + elapsed.String())
+	b.WriteString(" laps=" + strconv.Itoa(laps))
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		b.WriteString(" " + logfmtKey(k) + "=" + logfmtValue(tags[k]))
+	}
+
+	for i := 0; i < len(kvs); i += 2 {
+		var v interface{} = ""
+		if i+1 < len(kvs) {
+			v = kvs[i+1]
+		}
+		b.WriteString(" " + logfmtKey(fmt.Sprint(kvs[i])) + "=" + logfmtValue(fmt.Sprint(v)))
+	}
+	b.WriteString("\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// logfmtKey returns k without the characters that are invalid in a key.
+func logfmtKey(k string) string {
+	k = strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' {
+			return '_'
+		}
+		return r
+	}, k)
+	if k == "" {
+		return "_"
+	}
+	return k
+}
+
+// logfmtValue returns v, quoted if it is empty or contains spaces, equal
+// signs, quotes or control characters.
+func logfmtValue(v string) string {
+	if v == "" || strings.IndexFunc(v, func(r rune) bool { return r <= ' ' || r == '=' || r == '"' }) >= 0 {
+		return strconv.Quote(v)
+	}
+	return v
+}
@@ -0,0 +1,30 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_Logfmt(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	sw.SetTag("job id", "a b")
+	c.Advance(time.Second)
+	sw.Lap()
+	c.Advance(time.Second)
+
+	var buf bytes.Buffer
+	if err := sw.Logfmt(&buf, "sync users", "users", 42, "err", `bad "x"`, "dangling"); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	expected := "ts=" + c.Now().Format(time.RFC3339Nano) +
+		` msg="sync users" elapsed=2s laps=1 job_id="a b" users=42 err="bad \"x\"" dangling=""` + "\n"
+	if buf.String() != expected {
+		t.Errorf("Logfmt: got:\n%s\nexpected:\n%s\n", buf.String(), expected)
+	}
+}
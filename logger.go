@@ -0,0 +1,52 @@
+package stopwatch
+
+import (
+	"sync"
+	"time"
+)
+
+// Logger receives the lines of Log, such as an adapter to a structured
+// logging library. Tags are the tags of the stopwatch, see SetTag.
+type Logger interface {
+	LogElapsed(msg string, elapsed time.Duration, tags map[string]string)
+}
+
+// LoggerFunc is a function that implements Logger.
+type LoggerFunc func(msg string, elapsed time.Duration, tags map[string]string)
+
+// LogElapsed calls f.
+func (f LoggerFunc) LogElapsed(msg string, elapsed time.Duration, tags map[string]string) {
+	f(msg, elapsed, tags)
+}
+
+// defaultLogger is the Logger set with SetLogger.
+var defaultLogger struct {
+	sync.Mutex
+	l Logger
+}
+
+// SetLogger sets the Logger of all stopwatches without their own, see
+// WithLogger. A nil logger restores the standard log package.
+func SetLogger(l Logger) {
+	defaultLogger.Lock()
+	defaultLogger.l = l
+	defaultLogger.Unlock()
+}
+
+// WithLogger sets the Logger used by Log, instead of the one set with
+// SetLogger or the standard log package.
+func WithLogger(l Logger) Option {
+	return func(s *Stopwatch) { s.logger = l }
+}
+
+// loggerOrDefault returns the logger of s, the one set with SetLogger or nil
+// for the standard log package.
+func (s *Stopwatch) loggerOrDefault() Logger {
+	if s.logger != nil {
+		return s.logger
+	}
+
+	defaultLogger.Lock()
+	defer defaultLogger.Unlock()
+	return defaultLogger.l
+}
@@ -0,0 +1,41 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStopwatch_Logger(t *testing.T) {
+	c := newClock()
+	var got []string
+	record := func(prefix string) Logger {
+		return LoggerFunc(func(msg string, elapsed time.Duration, tags map[string]string) {
+			got = append(got, prefix+" "+msg+" "+elapsed.String()+" "+formatTags(tags))
+		})
+	}
+
+	SetLogger(record("default"))
+	defer SetLogger(nil)
+
+	sw := Start(0, WithClock(c))
+	sw.SetTag("job", "a")
+	c.Advance(time.Second)
+	sw.Log("sync")
+
+	own := Start(0, WithClock(c), WithLogger(record("own")))
+	c.Advance(time.Second)
+	own.Log("index")
+
+	expected := []string{"default sync 1s job=a", "own index 1s "}
+	if len(got) != len(expected) {
+		t.Fatalf("Log: got: %q expected: %q\n", got, expected)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Log: got: %q expected: %q\n", got[i], expected[i])
+		}
+	}
+}
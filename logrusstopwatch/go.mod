@@ -0,0 +1,12 @@
+module github.com/fatih/stopwatch/logrusstopwatch
+
+go 1.25.0
+
+require (
+	github.com/fatih/stopwatch v0.0.0-00010101000000-000000000000
+	github.com/sirupsen/logrus v1.10.2
+)
+
+require golang.org/x/sys v0.47.0 // indirect
+
+replace github.com/fatih/stopwatch => ../
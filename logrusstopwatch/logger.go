@@ -0,0 +1,25 @@
+// Package logrusstopwatch logs stopwatches with logrus, see
+// stopwatch.Logger.
+package logrusstopwatch
+
+import (
+	"time"
+
+	"github.com/fatih/stopwatch"
+	"github.com/sirupsen/logrus"
+)
+
+// Logger returns a stopwatch.Logger that logs with l at the info level. The
+// elapsed time is the "elapsed" field, the tags of the stopwatch are fields
+// as well. The elapsed time wins over a tag named "elapsed".
+// Example : stopwatch.SetLogger(logrusstopwatch.Logger(logrus.StandardLogger()))
+func Logger(l logrus.FieldLogger) stopwatch.Logger {
+	return stopwatch.LoggerFunc(func(msg string, elapsed time.Duration, tags map[string]string) {
+		fields := make(logrus.Fields, len(tags)+1)
+		for k, v := range tags {
+			fields[k] = v
+		}
+		fields["elapsed"] = elapsed
+		l.WithFields(fields).Info(msg)
+	})
+}
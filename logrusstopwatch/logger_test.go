@@ -0,0 +1,25 @@
+package logrusstopwatch
+
+import (
+	"testing"
+
+	"github.com/fatih/stopwatch"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestLogger(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	sw := stopwatch.Start(0, stopwatch.WithLogger(Logger(logger)))
+	sw.SetTag("job", "a")
+	sw.SetTag("elapsed", "tag")
+	sw.Stop()
+	sw.Log("sync")
+
+	e := hook.LastEntry()
+	if e == nil {
+		t.Fatal("Logger: nothing was logged")
+	}
+	if e.Message != "sync" || e.Data["job"] != "a" || e.Data["elapsed"] != sw.ElapsedTime() {
+		t.Errorf("Logger: got: %s %v expected: sync with job=a\n", e.Message, e.Data)
+	}
+}
@@ -0,0 +1,91 @@
+package stopwatch
+
+import (
+	"runtime"
+	"time"
+)
+
+// Allocs are the heap allocations of the process during a lap.
+type Allocs struct {
+	Bytes   uint64 // bytes allocated
+	Objects uint64 // heap objects allocated
+}
+
+// GCPauses are the garbage collections of the process during a lap.
+type GCPauses struct {
+	Count uint32        // completed GC cycles
+	Total time.Duration // stop-the-world pause time
+}
+
+// memSnapshot holds the counters of runtime.MemStats used for the laps.
+type memSnapshot struct {
+	totalAlloc, mallocs uint64
+	pauseTotalNs        uint64
+	numGC               uint32
+}
+
+// readMemStats returns the current counters. It is a variable to be replaced
+// in tests.
+var readMemStats = func() memSnapshot {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return memSnapshot{
+		totalAlloc:   m.TotalAlloc,
+		mallocs:      m.Mallocs,
+		pauseTotalNs: m.PauseTotalNs,
+		numGC:        m.NumGC,
+	}
+}
+
+// WithMemStats records the heap allocations and GC pauses of every lap, see
+// LapAllocs and LapGCTime. It stops the world briefly on every lap.
+func WithMemStats() Option {
+	return func(s *Stopwatch) { s.mem = true }
+}
+
+// LapAllocs returns the allocations during the stored laps, in the same order
+// as Laps. They are zero if the stopwatch wasn't created with WithMemStats.
+func (s *Stopwatch) LapAllocs() []Allocs {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	allocs := make([]Allocs, 0, len(s.laps))
+	for _, lap := range s.lapRecords() {
+		allocs = append(allocs, lap.Allocs)
+	}
+	return allocs
+}
+
+// LapGCTime returns the GC stop-the-world pause time during the i-th stored
+// lap, the part of the lap duration the program was paused. It returns zero
+// if there is no such lap or if the stopwatch wasn't created with
+// WithMemStats.
+func (s *Stopwatch) LapGCTime(i int) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i < 0 || i >= len(s.laps) {
+		return 0
+	}
+	return s.laps[(s.lapHead+i)%len(s.laps)].GC.Total
+}
+
+// markMem returns the allocations and GC pauses since the previous mark. The
+// lock must be held.
+func (s *Stopwatch) markMem() (Allocs, GCPauses) {
+	if !s.mem {
+		return Allocs{}, GCPauses{}
+	}
+
+	m := readMemStats()
+	a := Allocs{
+		Bytes:   m.totalAlloc - s.memMark.totalAlloc,
+		Objects: m.mallocs - s.memMark.mallocs,
+	}
+	gc := GCPauses{
+		Count: m.numGC - s.memMark.numGC,
+		Total: time.Duration(m.pauseTotalNs - s.memMark.pauseTotalNs),
+	}
+	s.memMark = m
+	return a, gc
+}
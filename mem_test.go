@@ -0,0 +1,53 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_LapAllocs(t *testing.T) {
+	var m memSnapshot
+	defer func(f func() memSnapshot) { readMemStats = f }(readMemStats)
+	readMemStats = func() memSnapshot { return m }
+
+	m = memSnapshot{totalAlloc: 1000, mallocs: 10}
+	c := newClock()
+	sw := Start(0, WithClock(c), WithMemStats())
+
+	m = memSnapshot{totalAlloc: 1500, mallocs: 12}
+	c.Advance(time.Second)
+	sw.Lap()
+
+	m = memSnapshot{totalAlloc: 4500, mallocs: 42, pauseTotalNs: uint64(3 * time.Millisecond), numGC: 2}
+	c.Advance(time.Second)
+	sw.Lap()
+
+	expected := []Allocs{{Bytes: 500, Objects: 2}, {Bytes: 3000, Objects: 30}}
+	if allocs := sw.LapAllocs(); !reflect.DeepEqual(allocs, expected) {
+		t.Errorf("LapAllocs: got: %v expected: %v\n", allocs, expected)
+	}
+
+	if d := sw.LapGCTime(1); d != 3*time.Millisecond {
+		t.Errorf("LapGCTime: got: %s expected: 3ms\n", d)
+	}
+	if gc := sw.LapRecords()[1].GC; gc.Count != 2 {
+		t.Errorf("LapRecords: got: %+v expected 2 GC cycles\n", gc)
+	}
+	if d := sw.LapGCTime(0); d != 0 {
+		t.Errorf("LapGCTime: got: %s expected: 0\n", d)
+	}
+	if d := sw.LapGCTime(2); d != 0 {
+		t.Errorf("LapGCTime: got: %s expected: 0 for a missing lap\n", d)
+	}
+
+	sw = Start(0, WithClock(c))
+	c.Advance(time.Second)
+	sw.Lap()
+	if allocs := sw.LapAllocs(); allocs[0] != (Allocs{}) {
+		t.Errorf("LapAllocs: got: %v expected zero allocations without WithMemStats\n", allocs)
+	}
+}
@@ -0,0 +1,203 @@
+package stopwatch
+
+import (
+	"bufio"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"time"
+)
+
+// RequestTiming is the timing of a single HTTP request measured by
+// Middleware.
+type RequestTiming struct {
+	Method  string
+	Route   string
+	Status  int
+	Elapsed time.Duration
+	Laps    []time.Duration
+}
+
+// MiddlewareOption configures Middleware.
+type MiddlewareOption func(*middleware)
+
+// WithRecorder calls fn with the timing of every completed request instead of
+// logging it.
+func WithRecorder(fn func(*http.Request, RequestTiming)) MiddlewareOption {
+	return func(m *middleware) { m.record = fn }
+}
+
+// WithRoute sets the function that returns the route of a request, such as
+// the pattern of a router. It defaults to the path of the request URL.
+func WithRoute(fn func(*http.Request) string) MiddlewareOption {
+	return func(m *middleware) { m.route = fn }
+}
+
+// WithStopwatchOptions sets the options of the stopwatches created for each
+// request.
+func WithStopwatchOptions(opts ...Option) MiddlewareOption {
+	return func(m *middleware) { m.opts = opts }
+}
+
+type middleware struct {
+	next   http.Handler
+	record func(*http.Request, RequestTiming)
+	route  func(*http.Request) string
+	opts   []Option
+}
+
+// Middleware times every request handled by next. It starts a Stopwatch per
+// request and stores it in the request context, handlers can retrieve it
+// with FromContext to take laps or open sections. The total is logged with
+// log.Printf once the request is completed, unless a recorder is set with
+// WithRecorder.
+func Middleware(next http.Handler, opts ...MiddlewareOption) http.Handler {
+	m := &middleware{
+		next:  next,
+		route: func(r *http.Request) string { return r.URL.Path },
+		record: func(r *http.Request, t RequestTiming) {
+			log.Printf("%s %s %d - elapsed: %s\n", t.Method, t.Route, t.Status, t.Elapsed)
+		},
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+func (m *middleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s := Start(0, m.opts...)
+	sw := &statusWriter{ResponseWriter: w}
+
+	r = r.WithContext(NewContext(r.Context(), s))
+	m.next.ServeHTTP(sw.wrap(), r)
+	s.Stop()
+
+	if sw.status == 0 {
+		sw.status = http.StatusOK
+	}
+
+	m.record(r, RequestTiming{
+		Method:  r.Method,
+		Route:   m.route(r),
+		Status:  sw.status,
+		Elapsed: s.ElapsedTime(),
+		Laps:    s.Laps(),
+	})
+}
+
+// statusWriter records the status code written to a http.ResponseWriter.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying writer for http.ResponseController.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// wrap returns w implementing the same optional interfaces as the underlying
+// writer: http.Flusher, http.Hijacker and io.ReaderFrom. So websocket
+// upgrades and sendfile work through the middleware, and handlers checking
+// for an interface see the truth.
+func (w *statusWriter) wrap() http.ResponseWriter {
+	_, fl := w.ResponseWriter.(http.Flusher)
+	_, hj := w.ResponseWriter.(http.Hijacker)
+	_, rf := w.ResponseWriter.(io.ReaderFrom)
+	f, h, r := flusher{w}, hijacker{w}, readerFrom{w}
+
+	switch {
+	case fl && hj && rf:
+		return struct {
+			*statusWriter
+			http.Flusher
+			http.Hijacker
+			io.ReaderFrom
+		}{w, f, h, r}
+	case fl && hj:
+		return struct {
+			*statusWriter
+			http.Flusher
+			http.Hijacker
+		}{w, f, h}
+	case fl && rf:
+		return struct {
+			*statusWriter
+			http.Flusher
+			io.ReaderFrom
+		}{w, f, r}
+	case hj && rf:
+		return struct {
+			*statusWriter
+			http.Hijacker
+			io.ReaderFrom
+		}{w, h, r}
+	case fl:
+		return struct {
+			*statusWriter
+			http.Flusher
+		}{w, f}
+	case hj:
+		return struct {
+			*statusWriter
+			http.Hijacker
+		}{w, h}
+	case rf:
+		return struct {
+			*statusWriter
+			io.ReaderFrom
+		}{w, r}
+	}
+	return w
+}
+
+// flusher forwards http.Flusher, flushing writes the status 200 if none was
+// written yet.
+type flusher struct{ w *statusWriter }
+
+func (f flusher) Flush() {
+	if f.w.status == 0 {
+		f.w.status = http.StatusOK
+	}
+	f.w.ResponseWriter.(http.Flusher).Flush()
+}
+
+// hijacker forwards http.Hijacker. A hijacked connection without a status
+// written yet is recorded as switching protocols, such as for websockets.
+type hijacker struct{ w *statusWriter }
+
+func (h hijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := h.w.ResponseWriter.(http.Hijacker).Hijack()
+	if err == nil && h.w.status == 0 {
+		h.w.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// readerFrom forwards io.ReaderFrom, which uses sendfile for files.
+type readerFrom struct{ w *statusWriter }
+
+func (r readerFrom) ReadFrom(src io.Reader) (int64, error) {
+	if r.w.status == 0 {
+		r.w.status = http.StatusOK
+	}
+	return r.w.ResponseWriter.(io.ReaderFrom).ReadFrom(src)
+}
@@ -0,0 +1,107 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s := FromContext(r.Context())
+		if s == nil {
+			t.Fatal("Middleware: request context should carry a stopwatch")
+		}
+
+		s.Lap()
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	var got RequestTiming
+	h := Middleware(handler,
+		WithRoute(func(*http.Request) string { return "/users/{id}" }),
+		WithRecorder(func(r *http.Request, t RequestTiming) { got = t }),
+	)
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users/42", nil))
+
+	if got.Method != "GET" || got.Route != "/users/{id}" || got.Status != http.StatusTeapot {
+		t.Errorf("Middleware: unexpected timing %+v\n", got)
+	}
+
+	if len(got.Laps) != 1 || got.Elapsed <= 0 {
+		t.Errorf("Middleware: got: %d laps and %s elapsed\n", len(got.Laps), got.Elapsed)
+	}
+
+	// implicit status code
+	h = Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}), WithRecorder(func(r *http.Request, t RequestTiming) { got = t }))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	if got.Status != http.StatusOK || got.Route != "/" {
+		t.Errorf("Middleware: unexpected timing %+v\n", got)
+	}
+}
+
+func TestMiddleware_Hijack(t *testing.T) {
+	var got RequestTiming
+	recorded := make(chan struct{})
+	h := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := w.(io.ReaderFrom); !ok {
+			t.Error("Middleware: the writer should implement io.ReaderFrom")
+		}
+
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("Hijack: error: %s\n", err)
+			return
+		}
+		defer conn.Close()
+
+		rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n\r\nhello")
+		rw.Flush()
+	}), WithRecorder(func(r *http.Request, t RequestTiming) {
+		got = t
+		close(recorded)
+	}))
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	defer conn.Close()
+
+	io.WriteString(conn, "GET /ws HTTP/1.1\r\nHost: test\r\n\r\n")
+	data, err := io.ReadAll(conn)
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	if !strings.HasSuffix(string(data), "\r\n\r\nhello") {
+		t.Errorf("Hijack: got: %q expected the hijacked response\n", data)
+	}
+
+	<-recorded
+	if got.Status != http.StatusSwitchingProtocols {
+		t.Errorf("Middleware: got status: %d expected: %d\n", got.Status, http.StatusSwitchingProtocols)
+	}
+
+	// httptest.ResponseRecorder can't be hijacked, neither can its wrapper
+	Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := w.(http.Hijacker); ok {
+			t.Error("Middleware: the writer should not implement http.Hijacker")
+		}
+		if _, ok := w.(http.Flusher); !ok {
+			t.Error("Middleware: the writer should implement http.Flusher")
+		}
+	}), WithRecorder(func(*http.Request, RequestTiming) {})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+}
@@ -0,0 +1,120 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// hasMonotonic reports whether t carries a monotonic clock reading, which
+// makes the durations measured from it immune to wall clock changes. Round(0)
+// strips the reading, see the time package.
+func hasMonotonic(t time.Time) bool {
+	return t != t.Round(0)
+}
+
+// checkMonotonic fails if one of the readings of sw lost its monotonic clock
+// reading.
+func checkMonotonic(t *testing.T, name string, sw *Stopwatch) {
+	t.Helper()
+
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	times := map[string]time.Time{"start": sw.start, "lap": sw.lap}
+	if sw.isStopped() {
+		times["stop"] = sw.stop
+	}
+	for _, c := range sw.sections {
+		if c.IsOpen() {
+			times["section "+c.Name] = c.Start
+		}
+	}
+
+	for field, v := range times {
+		if !hasMonotonic(v) {
+			t.Errorf("%s: %s has no monotonic clock reading: %s\n", name, field, v)
+		}
+	}
+}
+
+func TestStopwatch_WallClockJump(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	end := sw.Section("load")
+
+	c.Advance(time.Second)
+	c.Jump(-time.Hour)
+	sw.Lap()
+
+	c.Advance(time.Second)
+	c.Jump(2 * time.Hour)
+	end()
+
+	sw.Stop()
+	c.Jump(time.Hour)
+	sw.Start(0)
+	c.Advance(time.Second)
+	c.Jump(-24 * time.Hour)
+
+	if got := sw.ElapsedTime(); got != 3*time.Second {
+		t.Errorf("ElapsedTime: got %s expected 3s\n", got)
+	}
+	if laps := sw.Laps(); len(laps) != 1 || laps[0] != time.Second {
+		t.Errorf("Laps: got %v expected a lap of 1s\n", laps)
+	}
+	if d := sw.Sections()[0].Elapsed(); d != 2*time.Second {
+		t.Errorf("Sections: got %s expected 2s\n", d)
+	}
+
+	lf := Start(0, WithClock(c), WithLockFreeElapsed())
+	c.Advance(time.Second)
+	c.Jump(-time.Hour)
+	if got := lf.ElapsedTime(); got != time.Second {
+		t.Errorf("WithLockFreeElapsed: got %s expected 1s\n", got)
+	}
+}
+
+func TestStopwatch_Monotonic(t *testing.T) {
+	sw := Start(0)
+	sw.Lap()
+	checkMonotonic(t, "Start", sw)
+
+	sw.Stop()
+	checkMonotonic(t, "Stop", sw)
+
+	sw.Start(0)
+	checkMonotonic(t, "Start after Stop", sw)
+
+	sw.SetElapsed(time.Hour)
+	checkMonotonic(t, "SetElapsed", sw)
+
+	sw.Restart()
+	checkMonotonic(t, "Restart", sw)
+
+	checkMonotonic(t, "Resume", Resume(time.Hour, true))
+	checkMonotonic(t, "WithSpeed", Start(0, WithSpeed(2)))
+
+	var v Stopwatch
+	if err := json.Unmarshal([]byte(`"1h"`), &v); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	checkMonotonic(t, "UnmarshalJSON", &v)
+
+	// checkpoints only have wall clock times, open sections are rebuilt
+	sw = Start(0)
+	sw.Section("load")
+	var buf bytes.Buffer
+	if err := sw.WriteCheckpoint(&buf); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	restored, err := ReadCheckpoint(&buf)
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	checkMonotonic(t, "ReadCheckpoint", restored)
+}
@@ -0,0 +1,154 @@
+// Package otelstopwatch bridges stopwatches to OpenTelemetry spans.
+package otelstopwatch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/fatih/stopwatch"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option configures a bridge.
+type Option func(*Bridged)
+
+// WithSpanFromContext attaches to the span carried by the context given to
+// Bridge instead of opening a new one. Laps and sections are recorded as
+// events of that span, which is never ended by the bridge.
+func WithSpanFromContext() Option {
+	return func(b *Bridged) { b.attach = true }
+}
+
+// WithAttributes sets attributes on the spans opened by the bridge.
+func WithAttributes(attrs ...attribute.KeyValue) Option {
+	return func(b *Bridged) { b.attrs = attrs }
+}
+
+// Bridged is a stopwatch mirrored as spans, see Bridge.
+type Bridged struct {
+	ctx    context.Context
+	tracer trace.Tracer
+	name   string
+	attach bool
+	attrs  []attribute.KeyValue
+	remove func()
+
+	mu      sync.Mutex
+	span    trace.Span
+	spanCtx context.Context // carries span
+}
+
+// Start creates a new running stopwatch bridged to a span with the given
+// name, see Bridge.
+func Start(ctx context.Context, tracer trace.Tracer, name string, opts ...Option) (*stopwatch.Stopwatch, *Bridged) {
+	s := stopwatch.Start(0)
+	return s, Bridge(ctx, tracer, name, s, opts...)
+}
+
+// Bridge mirrors s as spans of the given tracer. A span is opened whenever
+// the stopwatch starts and ended when it is stopped or reseted, as a child
+// of the span in ctx. If s is already running the span is opened
+// immediately. Laps and sections are added as span events.
+// Example : defer otelstopwatch.Bridge(ctx, tracer, "import", s).Detach()
+func Bridge(ctx context.Context, tracer trace.Tracer, name string, s *stopwatch.Stopwatch, opts ...Option) *Bridged {
+	b := &Bridged{ctx: ctx, tracer: tracer, name: name}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	if b.attach {
+		b.span, b.spanCtx = trace.SpanFromContext(ctx), ctx
+	} else if !s.IsStopped() && !s.IsReseted() {
+		b.open(stopwatch.Event{Kind: stopwatch.EventStart, Time: time.Now(), Elapsed: s.ElapsedTime()})
+	}
+
+	b.remove = s.OnEvent(b.handle)
+	return b
+}
+
+// Context returns a context carrying the span of the current running period
+// of the stopwatch, to start child spans. It returns the context given to
+// Bridge if no span is open.
+func (b *Bridged) Context() context.Context {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.spanCtx == nil {
+		return b.ctx
+	}
+	return b.spanCtx
+}
+
+// Detach stops mirroring the stopwatch and ends the open span. It can be
+// called more than once.
+func (b *Bridged) Detach() {
+	b.remove()
+	if !b.attach {
+		b.end(time.Now())
+	}
+}
+
+// open starts a new span backdated by the elapsed time of the event.
+func (b *Bridged) open(e stopwatch.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.span != nil {
+		return
+	}
+
+	b.spanCtx, b.span = b.tracer.Start(b.ctx, b.name,
+		trace.WithTimestamp(e.Time.Add(-e.Elapsed)),
+		trace.WithAttributes(b.attrs...),
+	)
+}
+
+// end ends the open span at t.
+func (b *Bridged) end(t time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.span != nil {
+		b.span.End(trace.WithTimestamp(t))
+		b.span, b.spanCtx = nil, nil
+	}
+}
+
+func (b *Bridged) handle(e stopwatch.Event) {
+	switch e.Kind {
+	case stopwatch.EventStart:
+		if !b.attach {
+			b.open(e)
+		}
+	case stopwatch.EventStop, stopwatch.EventReset:
+		if !b.attach {
+			b.end(e.Time)
+		}
+	case stopwatch.EventLap:
+		b.addEvent("lap", e, attribute.Int64("duration_ns", int64(e.Duration)))
+	case stopwatch.EventSectionStart:
+		b.addEvent("section_start", e, attribute.String("section", e.Section))
+	case stopwatch.EventSectionEnd:
+		b.addEvent("section_end", e,
+			attribute.String("section", e.Section),
+			attribute.Int64("duration_ns", int64(e.Duration)),
+		)
+	}
+}
+
+func (b *Bridged) addEvent(name string, e stopwatch.Event, attrs ...attribute.KeyValue) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.span == nil {
+		return
+	}
+
+	attrs = append(attrs,
+		attribute.Int64("seq", int64(e.Seq)),
+		attribute.Int64("elapsed_ns", int64(e.Elapsed)),
+	)
+	b.span.AddEvent(name, trace.WithTimestamp(e.Time), trace.WithAttributes(attrs...))
+}
@@ -0,0 +1,99 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package otelstopwatch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fatih/stopwatch"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestBridge(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)).Tracer("test")
+
+	sw, b := Start(context.Background(), tracer, "job")
+	ctx := b.Context()
+	sw.Lap()
+	sw.Section("load")()
+	sw.Stop()
+
+	spans := rec.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Bridge: got: %d spans expected: %d\n", len(spans), 1)
+	}
+
+	if spans[0].Name() != "job" {
+		t.Errorf("Bridge: got: %s expected: job\n", spans[0].Name())
+	}
+
+	var names []string
+	for _, e := range spans[0].Events() {
+		names = append(names, e.Name)
+	}
+
+	if len(names) != 3 || names[0] != "lap" || names[1] != "section_start" || names[2] != "section_end" {
+		t.Errorf("Bridge: got events %v\n", names)
+	}
+
+	// resuming opens a new span
+	sw.Start(0)
+	sw.Reset()
+	if n := len(rec.Ended()); n != 2 {
+		t.Errorf("Bridge: got: %d spans expected: %d\n", n, 2)
+	}
+
+	// attach to the span from the first context
+	parent, span := tracer.Start(ctx, "parent")
+	sw = stopwatch.Start(0)
+	Bridge(parent, tracer, "unused", sw, WithSpanFromContext())
+	sw.Lap()
+	sw.Stop()
+	span.End()
+
+	ended := rec.Ended()
+	last := ended[len(ended)-1]
+	if last.Name() != "parent" || len(last.Events()) != 1 {
+		t.Errorf("Bridge: an attached bridge should record events on the parent span\n")
+	}
+}
+
+func TestBridge_ContextDetach(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)).Tracer("test")
+
+	ctx := context.Background()
+	sw := stopwatch.New()
+	b := Bridge(ctx, tracer, "job", sw)
+	if b.Context() != ctx {
+		t.Error("Context: expected the context given to Bridge without a span")
+	}
+
+	// the span of a later start can parent child spans
+	sw.Start(0)
+	_, child := tracer.Start(b.Context(), "child")
+	child.End()
+
+	b.Detach()
+	b.Detach()
+	sw.Stop()
+	sw.Start(0)
+
+	ended := rec.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("Detach: got: %d spans expected: %d\n", len(ended), 2)
+	}
+
+	job := ended[1]
+	if job.Name() != "job" || ended[0].Parent().SpanID() != job.SpanContext().SpanID() {
+		t.Errorf("Context: the child span should be parented by the span of the stopwatch\n")
+	}
+
+	if b.Context() != ctx {
+		t.Error("Detach: expected no span after Detach")
+	}
+}
@@ -0,0 +1,128 @@
+package stopwatch
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Pool recycles stopwatches and the storage of their laps, which saves the
+// allocations of a stopwatch per request on hot paths. All stopwatches of a
+// pool are configured with the same options. It is safe for concurrent use.
+type Pool struct {
+	opts []Option
+	pool sync.Pool
+}
+
+// NewPool returns a pool of stopwatches configured with the given options.
+// Example : var timers = stopwatch.NewPool(stopwatch.WithMaxLaps(100))
+func NewPool(opts ...Option) *Pool {
+	p := &Pool{opts: opts}
+	p.pool.New = func() interface{} { return New(p.opts...) }
+	return p
+}
+
+// Get returns a running stopwatch, like Start(0).
+func (p *Pool) Get() *Stopwatch {
+	s := p.pool.Get().(*Stopwatch)
+	s.Start(0)
+	return s
+}
+
+// Put returns s to the pool. It is reseted first, so its hooks see the end
+// of the session. Then its state, hooks and tags are discarded, the starts
+// and stops scheduled with StartAt and StopAt are canceled and the goroutines
+// of Display, OnProgress, LogEvery, AutoSave and BindContext are ended. s
+// must not be used afterwards.
+// Example : defer timers.Put(s)
+func (p *Pool) Put(s *Stopwatch) {
+	s.recycle(p.opts)
+	p.pool.Put(s)
+}
+
+// addTask registers end, which ends a background goroutine of s such as the
+// one of Display, so Put can end it before s is reused. The returned function
+// unregisters it.
+func (s *Stopwatch) addTask(end func()) (remove func()) {
+	s.mu.Lock()
+	s.lastID++
+	id := s.lastID
+	if s.tasks == nil {
+		s.tasks = make(map[uint64]func())
+	}
+	s.tasks[id] = end
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		delete(s.tasks, id)
+		s.mu.Unlock()
+	}
+}
+
+// recycle turns s into a new reseted stopwatch with the given options,
+// keeping the storage of the laps. Scheduled starts and stops and background
+// goroutines are ended first, so they don't act on the next user of s.
+func (s *Stopwatch) recycle(opts []Option) {
+	// the hooks see the end of the session, such as the ones of a Group
+	if !s.IsReseted() {
+		s.Reset()
+	}
+
+	s.mu.Lock()
+	tasks := s.tasks
+	s.tasks = nil
+	s.mu.Unlock()
+
+	// the goroutines may lock s until they are done
+	for _, end := range tasks {
+		end()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// timers that fired already may be waiting for the lock, they see the
+	// new generation and do nothing
+	s.gen++
+	for timer := range s.timers {
+		timer.Stop()
+	}
+	s.disarmWatchdog()
+	s.endTrace()
+	if s.onLeak != nil {
+		runtime.SetFinalizer(s, nil)
+	}
+	s.resetLaps()
+	s.clear()
+	s.init(opts)
+}
+
+// clear resets all fields to their zero value, except for the lock, the
+// storage of the laps and the counters that must keep increasing, so the
+// functions returned by OnEvent and the timers of the previous user don't
+// affect the next one. The lock must be held.
+func (s *Stopwatch) clear() {
+	s.clock, s.speed, s.scaled = nil, 0, false
+	s.format, s.colors = nil, nil
+	s.tags, s.budgets = nil, nil
+	s.deadline, s.missed = time.Time{}, false
+	s.postmortems, s.watchdog, s.onLeak = false, nil, nil
+	s.callers, s.logger, s.lockFree = false, nil, false
+	s.caller, s.behavior, s.split, s.minLap, s.authority = "", 0, nil, 0, nil
+
+	s.start, s.stop, s.lap, s.stopped = time.Time{}, time.Time{}, time.Time{}, false
+	s.laps, s.lapHead, s.lapStats, s.lapBase, s.maxLaps = s.laps[:0], 0, LapStats{}, 0, 0
+	s.ewma, s.runs = nil, s.runs[:0]
+	s.history, s.maxHistory = nil, 0
+
+	s.sections, s.section = nil, nil
+	s.hooks, s.seq = nil, 0
+
+	s.total, s.done, s.notifications = 0, 0, nil
+	s.ticks, s.window, s.tickLog = 0, 0, nil
+	s.cpu, s.cpuMark, s.cpuTotal = false, 0, 0
+	s.mem, s.memMark = false, memSnapshot{}
+	s.timers, s.tasks = nil, nil
+	s.trace, s.traceCtx, s.task, s.lapRegion = false, nil, nil, nil
+}
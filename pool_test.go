@@ -0,0 +1,154 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPool(t *testing.T) {
+	c := newClock()
+	p := NewPool(WithClock(c))
+
+	sw := p.Get()
+	if sw.IsStopped() || sw.IsReseted() {
+		t.Error("Get: the stopwatch should be running")
+	}
+
+	sw.SetTag("request", "1")
+	var events []EventKind
+	sw.OnEvent(func(e Event) { events = append(events, e.Kind) })
+	c.Advance(time.Second)
+	p.Put(sw)
+
+	sw.mu.Lock()
+	if mc, _ := sw.clock.(*monotonicClock); mc == nil || mc.clock != c || len(sw.tags) != 0 || len(sw.hooks) != 0 || !sw.isReseted() {
+		t.Error("Put: the stopwatch should be reseted with the options of the pool")
+	}
+	sw.mu.Unlock()
+
+	sw.Start(0)
+	sw.Lap()
+	if len(events) != 1 || events[0] != EventReset {
+		t.Errorf("Put: got events: %v expected a reset, then the hooks discarded\n", events)
+	}
+}
+
+func TestPool_Pending(t *testing.T) {
+	p := NewPool()
+
+	sw := p.Get()
+	sw.StopAt(time.Now().Add(10 * time.Millisecond))
+	sw.StartAt(time.Now().Add(10 * time.Millisecond))
+	stop := sw.Display(io.Discard, time.Millisecond)
+	p.Put(sw)
+	stop()
+
+	if got := p.Get(); got != sw {
+		t.Skip("Get: the pool dropped the stopwatch")
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	if sw.IsStopped() {
+		t.Error("Put: a pending StopAt should not stop the next user")
+	}
+
+	sw.mu.Lock()
+	if len(sw.timers) != 0 || len(sw.tasks) != 0 || len(sw.hooks) != 0 {
+		t.Errorf("Put: got: %d timers %d tasks %d hooks expected none\n", len(sw.timers), len(sw.tasks), len(sw.hooks))
+	}
+	sw.mu.Unlock()
+}
+
+func TestPool_Clear(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c), WithMaxLaps(2), WithLockFreeElapsed(), WithHistory(2))
+	sw.SetTag("request", "1")
+	sw.OnEvent(func(Event) {})
+	sw.Section("load")
+	sw.Lap()
+	sw.Tick(1)
+	sw.Restart()
+
+	sw.recycle(nil)
+
+	// the fields kept by clear, all others must be zero
+	kept := map[string]bool{"published": true, "mu": true, "lfClock": true, "laps": true, "runs": true, "lastID": true, "gen": true}
+
+	v := reflect.ValueOf(sw).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Name
+		if !kept[name] && !v.Field(i).IsZero() {
+			t.Errorf("Put: the field %s is not cleared\n", name)
+		}
+	}
+
+	if len(sw.laps) != 0 || len(sw.runs) != 0 {
+		t.Errorf("Put: got %d laps and %d runs expected none\n", len(sw.laps), len(sw.runs))
+	}
+}
+
+func TestPool_StaleWatchdog(t *testing.T) {
+	c := newClock()
+	tripped := false
+	sw := Start(0, WithClock(c), WithWatchdog(time.Hour, func(*Stopwatch) { tripped = true }))
+	c.Advance(2 * time.Hour)
+
+	// a check that fired before Put and got the lock after it
+	sw.mu.Lock()
+	gen, sgen := sw.watchdog.gen, sw.gen
+	sw.gen++
+	sw.mu.Unlock()
+
+	sw.checkWatchdog(gen, sgen)
+	if tripped {
+		t.Error("Put: a stale watchdog check should do nothing")
+	}
+}
+
+func TestPool_Race(t *testing.T) {
+	p := NewPool(WithLockFreeElapsed(), WithWatchdog(time.Microsecond, func(*Stopwatch) {}))
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for i := 0; i < 100; i++ {
+		sw := p.Get()
+		sw.StopAt(time.Now())
+
+		// a reader that keeps reading after Put, against the documentation,
+		// must not race with the next user
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					sw.ElapsedTime()
+				}
+			}
+		}()
+
+		time.Sleep(10 * time.Microsecond)
+		p.Put(sw)
+	}
+	close(done)
+	wg.Wait()
+}
+
+func BenchmarkPool(b *testing.B) {
+	p := NewPool()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s := p.Get()
+		s.Lap()
+		s.Stop()
+		p.Put(s)
+	}
+}
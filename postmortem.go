@@ -0,0 +1,54 @@
+package stopwatch
+
+import (
+	"runtime"
+	"time"
+)
+
+// Postmortem is a diagnostic snapshot of the process and the stopwatch taken
+// at a point of interest, such as a missed deadline.
+type Postmortem struct {
+	Time       time.Time
+	Elapsed    time.Duration
+	Goroutines int
+	MemStats   runtime.MemStats
+
+	// Sections is the stack of open sections, outermost first.
+	Sections []string
+}
+
+// WithPostmortem attaches a postmortem to the events of crossed thresholds:
+// EventWatchdog, EventDeadline and EventBudget.
+// Example : stopwatch.Start(0, stopwatch.WithPostmortem())
+func WithPostmortem() Option {
+	return func(s *Stopwatch) { s.postmortems = true }
+}
+
+// Postmortem captures a diagnostic snapshot. It calls runtime.ReadMemStats,
+// which stops the world, therefore it shouldn't be used in hot paths.
+func (s *Stopwatch) Postmortem() *Postmortem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.postmortem(s.section)
+}
+
+// postmortem captures a diagnostic snapshot with the sections from the top
+// level down to c. The lock must be held.
+func (s *Stopwatch) postmortem(c *Section) *Postmortem {
+	p := &Postmortem{Goroutines: runtime.NumGoroutine(), Time: s.now(), Elapsed: s.elapsed()}
+	runtime.ReadMemStats(&p.MemStats)
+	for ; c != nil; c = c.parent {
+		p.Sections = append([]string{c.Name}, p.Sections...)
+	}
+	return p
+}
+
+// breach returns a threshold event of the given kind, with a postmortem down
+// to the section c if enabled. The lock must be held.
+func (s *Stopwatch) breach(kind EventKind, c *Section) Event {
+	e := s.event(kind)
+	if s.postmortems {
+		e.Postmortem = s.postmortem(c)
+	}
+	return e
+}
@@ -0,0 +1,100 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStopwatch_Postmortem(t *testing.T) {
+	sw := Start(0)
+	sw.Section("outer")
+	sw.Section("inner")
+
+	p := sw.Postmortem()
+
+	if p.Goroutines < 1 {
+		t.Errorf("Postmortem: got: %d goroutines\n", p.Goroutines)
+	}
+
+	if p.MemStats.Sys == 0 {
+		t.Error("Postmortem: memstats should be captured")
+	}
+
+	if len(p.Sections) != 2 || p.Sections[0] != "outer" || p.Sections[1] != "inner" {
+		t.Errorf("Postmortem: got: %v expected: [outer inner]\n", p.Sections)
+	}
+}
+
+func TestStopwatch_PostmortemEvents(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c), WithPostmortem())
+	sw.SetBudget("request/db", time.Second)
+	sw.SetDeadline(c.Now().Add(3 * time.Second))
+
+	var events []Event
+	sw.OnEvent(func(e Event) {
+		if e.Kind == EventBudget || e.Kind == EventDeadline {
+			events = append(events, e)
+		}
+	})
+
+	end := sw.Section("request")
+	db := sw.Section("db")
+	c.Advance(500 * time.Millisecond)
+	db()
+	db = sw.Section("db")
+	c.Advance(time.Second)
+	db()
+	db = sw.Section("db")
+	c.Advance(time.Second)
+	db()
+
+	c.Advance(time.Second)
+	end()
+	sw.Stop()
+
+	if len(events) != 2 {
+		t.Fatalf("Postmortem: got: %d threshold events expected: 2\n", len(events))
+	}
+
+	budget, deadline := events[0], events[1]
+	if budget.Kind != EventBudget || budget.Section != "request/db" || budget.Duration != 1500*time.Millisecond {
+		t.Errorf("EventBudget: got: %s %s %s expected: budget request/db 1.5s\n", budget.Kind, budget.Section, budget.Duration)
+	}
+	if p := budget.Postmortem; p == nil || len(p.Sections) != 2 || p.Sections[1] != "db" || p.MemStats.Sys == 0 {
+		t.Errorf("EventBudget: got postmortem: %+v expected the db section\n", p)
+	}
+
+	if deadline.Kind != EventDeadline || deadline.Duration != 500*time.Millisecond {
+		t.Errorf("EventDeadline: got: %s %s expected: deadline 500ms\n", deadline.Kind, deadline.Duration)
+	}
+	if p := deadline.Postmortem; p == nil || p.Elapsed != 3500*time.Millisecond || p.Goroutines < 1 {
+		t.Errorf("EventDeadline: got postmortem: %+v expected the elapsed time 3.5s\n", p)
+	}
+}
+
+func TestStopwatch_PostmortemWatchdog(t *testing.T) {
+	tripped := make(chan struct{})
+	sw := Start(0, WithPostmortem(), WithWatchdog(time.Millisecond, func(*Stopwatch) { close(tripped) }))
+
+	var p *Postmortem
+	sw.OnEvent(func(e Event) {
+		if e.Kind == EventWatchdog {
+			p = e.Postmortem
+		}
+	})
+	sw.Section("hung")
+
+	select {
+	case <-tripped:
+	case <-time.After(time.Second):
+		t.Fatal("WithWatchdog: onTrip was not called")
+	}
+
+	if p == nil || len(p.Sections) != 1 || p.Sections[0] != "hung" {
+		t.Errorf("EventWatchdog: got postmortem: %+v expected the hung section\n", p)
+	}
+}
@@ -0,0 +1,30 @@
+package stopwatch
+
+import (
+	"context"
+	"runtime/pprof"
+	"strconv"
+)
+
+// Do runs f in a new section with the given name, like Section, and labels
+// it for CPU profiles with pprof.Do: "section" is the path of the section and
+// "lap" the number of the current lap, on top of the labels of ctx. The
+// labels apply to f and the goroutines it starts with the context passed to
+// f, the previous labels of the goroutine are restored when f returns.
+// Example : s.Do(ctx, "parse", func(ctx context.Context) { parse(ctx) })
+// Profile : go tool pprof -tagfocus section=load/parse cpu.prof
+func (s *Stopwatch) Do(ctx context.Context, name string, f func(context.Context)) {
+	if !Enabled {
+		f(ctx)
+		return
+	}
+
+	c := s.openSection(name, s.callerOf())
+	defer s.endSection(c)
+
+	s.mu.Lock()
+	labels := pprof.Labels("section", c.path(), "lap", strconv.Itoa(s.lapStats.Count+1))
+	s.mu.Unlock()
+
+	pprof.Do(ctx, labels, f)
+}
@@ -0,0 +1,45 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"context"
+	"runtime/pprof"
+	"testing"
+)
+
+func TestStopwatch_Do(t *testing.T) {
+	sw := Start(0)
+	sw.Lap()
+	defer sw.Section("load")()
+
+	ctx := pprof.WithLabels(context.Background(), pprof.Labels("request", "1"))
+
+	called := false
+	sw.Do(ctx, "parse", func(ctx context.Context) {
+		called = true
+
+		label := func(key string) string {
+			v, _ := pprof.Label(ctx, key)
+			return v
+		}
+
+		if label("section") != "load/parse" || label("lap") != "2" {
+			t.Errorf("Do: got: section=%q lap=%q expected: section=load/parse lap=2\n", label("section"), label("lap"))
+		}
+
+		if label("request") != "1" {
+			t.Errorf("Do: got: request=%q expected the labels of the context to be kept\n", label("request"))
+		}
+	})
+
+	if !called {
+		t.Fatal("Do: f was not called")
+	}
+
+	sections := sw.Sections()
+	if len(sections) != 1 || len(sections[0].Children) != 1 || sections[0].Children[0].IsOpen() {
+		t.Errorf("Do: expected a closed section load/parse, got %v\n", sections)
+	}
+}
@@ -0,0 +1,195 @@
+package stopwatch
+
+import (
+	"sync"
+	"time"
+)
+
+// Progress is a snapshot of the progress of a session, see SetTotal and
+// Advance.
+type Progress struct {
+	Done, Total int
+	Percent     float64 // of the total done, zero if there is no total
+	Elapsed     time.Duration
+	ETA         time.Duration // zero if there is no estimation yet
+	Rate        float64       // items done per second of running time
+}
+
+// etaNotification is a callback registered with NotifyETA.
+type etaNotification struct {
+	within time.Duration
+	fn     func(eta time.Duration)
+	fired  bool
+}
+
+// SetTotal sets the number of work items of the session. Together with
+// Advance it is used to estimate the remaining time.
+func (s *Stopwatch) SetTotal(n int) {
+	if !Enabled {
+		return
+	}
+
+	s.mu.Lock()
+	s.total = n
+	s.mu.Unlock()
+}
+
+// Advance marks k more work items as done and fires the ETA notifications
+// whose threshold has been reached.
+func (s *Stopwatch) Advance(k int) {
+	if !Enabled {
+		return
+	}
+
+	s.mu.Lock()
+	s.done += k
+
+	var fire []func()
+	if eta, ok := s.eta(); ok {
+		for _, n := range s.notifications {
+			if !n.fired && eta <= n.within {
+				n.fired = true
+				fn := n.fn
+				fire = append(fire, func() { fn(eta) })
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	for _, fn := range fire {
+		fn()
+	}
+}
+
+// ETA returns the estimated remaining time of the session, based on the rate
+// at which work items were done so far. It returns zero if there is no total
+// or no item is done yet.
+func (s *Stopwatch) ETA() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	eta, _ := s.eta()
+	return eta
+}
+
+// CompletionTime returns the estimated time at which the session completes,
+// see ETA. It returns the zero time if there is not enough data for an
+// estimation.
+func (s *Stopwatch) CompletionTime() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	eta, ok := s.eta()
+	if !ok {
+		return time.Time{}
+	}
+	return s.now().Add(eta)
+}
+
+// eta returns the estimated remaining time. The boolean is false if there is
+// not enough data for an estimation.
+func (s *Stopwatch) eta() (time.Duration, bool) {
+	if s.total <= 0 || s.done <= 0 {
+		return 0, false
+	}
+
+	if s.done >= s.total {
+		return 0, true
+	}
+
+	elapsed := float64(s.elapsed())
+	return time.Duration(elapsed * float64(s.total-s.done) / float64(s.done)), true
+}
+
+// NotifyETA calls fn once, when the estimated remaining time drops to within
+// or below. The estimation is updated by Advance, which runs fn
+// synchronously. Notifications are rearmed for every new session.
+// Example : s.NotifyETA(time.Minute, func(eta time.Duration) { log.Println("nearly done") })
+func (s *Stopwatch) NotifyETA(within time.Duration, fn func(eta time.Duration)) {
+	s.mu.Lock()
+	s.notifications = append(s.notifications, &etaNotification{within: within, fn: fn})
+	s.mu.Unlock()
+}
+
+// Progress returns the current progress of the session.
+func (s *Stopwatch) Progress() Progress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.progress()
+}
+
+// progress returns the current progress. The lock must be held.
+func (s *Stopwatch) progress() Progress {
+	p := Progress{Done: s.done, Total: s.total, Elapsed: s.elapsed()}
+	p.ETA, _ = s.eta()
+	if s.total > 0 {
+		p.Percent = 100 * float64(s.done) / float64(s.total)
+	}
+	if p.Elapsed > 0 {
+		p.Rate = float64(s.done) / p.Elapsed.Seconds()
+	}
+	return p
+}
+
+// OnProgress calls fn with the current progress every interval while the
+// stopwatch is running, and a final time once it is stopped or reseted. The
+// returned function ends the reporting as well and waits until it is done,
+// it can be called more than once. The event hook ending the reporting is
+// removed once it is done. Useful to drive a progress bar or a
+// periodic log line.
+// Example : defer s.OnProgress(time.Second, func(p Progress) { log.Printf("%.0f%%", p.Percent) })()
+func (s *Stopwatch) OnProgress(interval time.Duration, fn func(Progress)) (stop func()) {
+	done := make(chan struct{})
+	var once sync.Once
+	end := func() { once.Do(func() { close(done) }) }
+
+	remove := s.OnEvent(func(e Event) {
+		if e.Kind == EventStop || e.Kind == EventReset {
+			end()
+		}
+	})
+
+	finished := make(chan struct{})
+	stop = func() {
+		end()
+		<-finished
+	}
+	untrack := s.addTask(stop)
+
+	go func() {
+		defer close(finished)
+		defer untrack()
+		defer remove()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			s.mu.Lock()
+			p, running := s.progress(), s.isRunning()
+			s.mu.Unlock()
+
+			fn(p)
+			if !running {
+				return
+			}
+
+			select {
+			case <-done:
+				fn(s.Progress())
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return stop
+}
+
+// resetProgress clears the progress of the session. The lock must be held.
+func (s *Stopwatch) resetProgress() {
+	s.done = 0
+	for _, n := range s.notifications {
+		n.fired = false
+	}
+}
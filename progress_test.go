@@ -0,0 +1,112 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_ETA(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+
+	if eta := sw.ETA(); eta != 0 {
+		t.Errorf("ETA: got: %s expected: 0\n", eta)
+	}
+	if done := sw.CompletionTime(); !done.IsZero() {
+		t.Errorf("CompletionTime: got: %s expected the zero time\n", done)
+	}
+
+	sw.SetTotal(100)
+	c.Advance(10 * time.Second)
+	sw.Advance(25)
+
+	if eta := sw.ETA(); eta != 30*time.Second {
+		t.Errorf("ETA: got: %s expected: %s\n", eta, 30*time.Second)
+	}
+	if done := sw.CompletionTime(); !done.Equal(c.Now().Add(30 * time.Second)) {
+		t.Errorf("CompletionTime: got: %s expected: %s\n", done, c.Now().Add(30*time.Second))
+	}
+
+	sw.Advance(75)
+	if eta := sw.ETA(); eta != 0 {
+		t.Errorf("ETA: got: %s expected: 0\n", eta)
+	}
+}
+
+func TestStopwatch_NotifyETA(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	sw.SetTotal(10)
+
+	var fired []time.Duration
+	sw.NotifyETA(5*time.Second, func(eta time.Duration) { fired = append(fired, eta) })
+
+	for i := 0; i < 10; i++ {
+		c.Advance(time.Second)
+		sw.Advance(1)
+	}
+
+	if len(fired) != 1 || fired[0] != 5*time.Second {
+		t.Errorf("NotifyETA: got: %v expected: [5s]\n", fired)
+	}
+
+	sw.Reset()
+	sw.Start(0)
+	c.Advance(time.Second)
+	sw.Advance(9)
+
+	if len(fired) != 2 {
+		t.Errorf("NotifyETA: notifications should be rearmed for a new session, got: %v\n", fired)
+	}
+}
+
+func TestStopwatch_OnProgress(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	sw.SetTotal(100)
+
+	c.Advance(10 * time.Second)
+	sw.Advance(25)
+
+	p := sw.Progress()
+	if p.Done != 25 || p.Total != 100 || p.Percent != 25 || p.Elapsed != 10*time.Second ||
+		p.ETA != 30*time.Second || p.Rate != 2.5 {
+		t.Errorf("Progress: got: %+v\n", p)
+	}
+
+	var mu sync.Mutex
+	var got []Progress
+	stop := sw.OnProgress(time.Millisecond, func(p Progress) {
+		mu.Lock()
+		got = append(got, p)
+		mu.Unlock()
+	})
+
+	c.Advance(10 * time.Second)
+	sw.Advance(75)
+	sw.Stop()
+	stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got) == 0 {
+		t.Fatal("OnProgress: expected fn to be called")
+	}
+	if last := got[len(got)-1]; last.Percent != 100 || last.ETA != 0 {
+		t.Errorf("OnProgress: got: %+v expected a final call with the completed progress\n", last)
+	}
+
+	if n := hookCount(sw); n != 0 {
+		t.Errorf("OnProgress: got: %d hooks after stop expected: 0\n", n)
+	}
+
+	sw.Start(0)
+	sw.OnProgress(time.Millisecond, func(Progress) {})()
+	if n := hookCount(sw); n != 0 {
+		t.Errorf("OnProgress: got: %d hooks after stop expected: 0\n", n)
+	}
+}
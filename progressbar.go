@@ -0,0 +1,76 @@
+package stopwatch
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// progressBarWidth is the width of the bar drawn by ProgressBar if no width
+// is given.
+const progressBarWidth = 40
+
+// Intervals at which ProgressBar redraws the bar on a terminal and writes a
+// plain line otherwise. They are variables to be replaced in tests.
+var (
+	progressBarInterval  = 100 * time.Millisecond
+	progressLineInterval = 10 * time.Second
+)
+
+// ProgressBar draws a progress bar of width characters to w, with the
+// percentage done, the elapsed time, the ETA and the rate, see SetTotal and
+// Advance. On a terminal the bar is redrawn in place, otherwise a plain line
+// is written every 10 seconds, so redirected logs stay readable. The bar ends
+// with a final update once the stopwatch is stopped or reseted. The returned
+// function ends the bar as well and waits until it is done, it can be called
+// more than once.
+// Example : defer s.ProgressBar(os.Stderr, 40)()
+func (s *Stopwatch) ProgressBar(w io.Writer, width int) (stop func()) {
+	if width <= 0 {
+		width = progressBarWidth
+	}
+
+	if !isTerminal(w) {
+		return s.OnProgress(progressLineInterval, func(p Progress) {
+			io.WriteString(w, s.progressLine(p)+"\n")
+		})
+	}
+
+	end := s.OnProgress(progressBarInterval, func(p Progress) {
+		io.WriteString(w, "\r\x1b[2K"+progressBar(p, width)+" "+s.progressLine(p))
+	})
+
+	var once sync.Once
+	return func() {
+		end()
+		once.Do(func() { io.WriteString(w, "\n") })
+	}
+}
+
+// progressBar returns the bar of p, filled by the percentage done.
+func progressBar(p Progress, width int) string {
+	filled := int(p.Percent / 100 * float64(width))
+	if filled < 0 {
+		filled = 0
+	} else if filled > width {
+		filled = width
+	}
+	return "[" + strings.Repeat("#", filled) + strings.Repeat("-", width-filled) + "]"
+}
+
+// progressLine returns the progress of p as text, such as
+// "25.0% (1/4) elapsed: 2s eta: 6s rate: 0.5/s".
+func (s *Stopwatch) progressLine(p Progress) string {
+	eta := "-"
+	if p.ETA > 0 || (p.Total > 0 && p.Done >= p.Total) {
+		eta = s.formatDuration(p.ETA)
+	}
+
+	done := fmt.Sprintf("%d", p.Done)
+	if p.Total > 0 {
+		done = fmt.Sprintf("%5.1f%% (%d/%d)", p.Percent, p.Done, p.Total)
+	}
+	return fmt.Sprintf("%s elapsed: %s eta: %s rate: %.1f/s", done, s.formatDuration(p.Elapsed), eta, p.Rate)
+}
@@ -0,0 +1,69 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_ProgressBar(t *testing.T) {
+	defer func(f func(io.Writer) bool) { isTerminal = f }(isTerminal)
+	defer func(d time.Duration) { progressBarInterval = d }(progressBarInterval)
+	isTerminal = func(io.Writer) bool { return true }
+	progressBarInterval = time.Millisecond
+
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	sw.SetTotal(4)
+	c.Advance(2 * time.Second)
+	sw.Advance(1)
+
+	var buf syncBuffer
+	stop := sw.ProgressBar(&buf, 20)
+	time.Sleep(5 * time.Millisecond)
+	sw.Stop()
+	stop()
+	stop()
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "\r\x1b[2K") || !strings.HasSuffix(out, "\n") || strings.Count(out, "\n") != 1 {
+		t.Fatalf("ProgressBar: got: %q expected redrawn bar ending with a newline\n", out)
+	}
+
+	draws := strings.Split(strings.TrimSuffix(out, "\n"), "\r\x1b[2K")
+	expected := "[#####---------------]  25.0% (1/4) elapsed: 2s eta: 6s rate: 0.5/s"
+	if got := draws[len(draws)-1]; got != expected {
+		t.Errorf("ProgressBar: got: %q expected: %q\n", got, expected)
+	}
+
+	if n := hookCount(sw); n != 0 {
+		t.Errorf("ProgressBar: got: %d hooks after stop expected: 0\n", n)
+	}
+}
+
+func TestStopwatch_ProgressBarPlain(t *testing.T) {
+	defer func(d time.Duration) { progressLineInterval = d }(progressLineInterval)
+	progressLineInterval = time.Millisecond
+
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	c.Advance(time.Second)
+	sw.Advance(3)
+
+	var buf syncBuffer
+	stop := sw.ProgressBar(&buf, 0)
+	time.Sleep(5 * time.Millisecond)
+	sw.Stop()
+	stop()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	for _, line := range lines {
+		if line != "3 elapsed: 1s eta: - rate: 3.0/s" {
+			t.Fatalf("ProgressBar: got line: %q expected: 3 elapsed: 1s eta: - rate: 3.0/s\n", line)
+		}
+	}
+}
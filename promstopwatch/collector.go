@@ -0,0 +1,112 @@
+// Package promstopwatch exports stopwatches as Prometheus metrics.
+package promstopwatch
+
+import (
+	"sync"
+
+	"github.com/fatih/stopwatch"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Opts configures a Collector.
+type Opts struct {
+	Namespace string
+	Subsystem string
+
+	// Buckets of the lap histogram in seconds. Defaults to
+	// prometheus.DefBuckets.
+	Buckets []float64
+}
+
+// Collector implements the prometheus.Collector interface. It exports the
+// elapsed time of each watched stopwatch as a gauge and its laps as a
+// histogram, both labeled with the name the stopwatch is watched under.
+type Collector struct {
+	elapsed *prometheus.Desc
+	laps    *prometheus.HistogramVec
+
+	mu      sync.Mutex
+	watches map[string]*stopwatch.Stopwatch
+	removes map[string]func() // removes the lap hooks of the watches
+}
+
+// NewCollector creates a new Collector. It needs to be registered with a
+// prometheus.Registerer to be scraped.
+func NewCollector(opts Opts) *Collector {
+	return &Collector{
+		elapsed: prometheus.NewDesc(
+			prometheus.BuildFQName(opts.Namespace, opts.Subsystem, "stopwatch_elapsed_seconds"),
+			"Elapsed time of the stopwatch.",
+			[]string{"name"}, nil,
+		),
+		laps: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: opts.Namespace,
+			Subsystem: opts.Subsystem,
+			Name:      "stopwatch_lap_duration_seconds",
+			Help:      "Duration of the stopwatch laps.",
+			Buckets:   opts.Buckets,
+		}, []string{"name"}),
+		watches: make(map[string]*stopwatch.Stopwatch),
+		removes: make(map[string]func()),
+	}
+}
+
+// Watch exports s under the given name. Every lap taken from now on is
+// observed by the lap histogram. Watching another stopwatch under the same
+// name replaces the previous one.
+func (c *Collector) Watch(name string, s *stopwatch.Stopwatch) {
+	remove := s.OnEvent(func(e stopwatch.Event) {
+		if e.Kind != stopwatch.EventLap {
+			return
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		// the stopwatch might be unwatched or replaced in the meantime
+		if c.watches[name] == s {
+			c.laps.WithLabelValues(name).Observe(e.Duration.Seconds())
+		}
+	})
+
+	c.mu.Lock()
+	previous := c.removes[name]
+	c.watches[name], c.removes[name] = s, remove
+	c.mu.Unlock()
+
+	if previous != nil {
+		previous()
+	}
+}
+
+// Unwatch removes the stopwatch with the given name and its metrics.
+func (c *Collector) Unwatch(name string) {
+	c.mu.Lock()
+	remove := c.removes[name]
+	delete(c.watches, name)
+	delete(c.removes, name)
+	c.laps.DeleteLabelValues(name)
+	c.mu.Unlock()
+
+	if remove != nil {
+		remove()
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.elapsed
+	c.laps.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	for name, s := range c.watches {
+		ch <- prometheus.MustNewConstMetric(c.elapsed, prometheus.GaugeValue,
+			s.ElapsedTime().Seconds(), name)
+	}
+	c.mu.Unlock()
+
+	c.laps.Collect(ch)
+}
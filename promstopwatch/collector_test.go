@@ -0,0 +1,62 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package promstopwatch
+
+import (
+	"testing"
+
+	"github.com/fatih/stopwatch"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestCollector(t *testing.T) {
+	c := NewCollector(Opts{Namespace: "test"})
+
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(c); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	sw := stopwatch.Start(0)
+	c.Watch("build", sw)
+	sw.Lap()
+	sw.Lap()
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	found := make(map[string]bool)
+	for _, f := range families {
+		found[f.GetName()] = true
+
+		if f.GetName() == "test_stopwatch_lap_duration_seconds" {
+			h := f.GetMetric()[0].GetHistogram()
+			if h.GetSampleCount() != 2 {
+				t.Errorf("Collector: got: %d laps expected: %d\n", h.GetSampleCount(), 2)
+			}
+		}
+	}
+
+	if !found["test_stopwatch_elapsed_seconds"] || !found["test_stopwatch_lap_duration_seconds"] {
+		t.Errorf("Collector: missing metric families %v\n", found)
+	}
+
+	c.Unwatch("build")
+	sw.Lap()
+
+	families, err = reg.Gather()
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	if len(families) != 0 {
+		t.Errorf("Collector: got: %d metric families after Unwatch\n", len(families))
+	}
+
+	if len(c.removes) != 0 {
+		t.Errorf("Unwatch: got: %d lap hooks expected: 0\n", len(c.removes))
+	}
+}
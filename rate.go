@@ -0,0 +1,102 @@
+package stopwatch
+
+import "time"
+
+// tick is a number of items recorded by Tick at a certain time.
+type tick struct {
+	t time.Time
+	n int
+}
+
+// WithRateWindow keeps the ticks of the latest d, which are used by
+// WindowRate to compute the current rate.
+func WithRateWindow(d time.Duration) Option {
+	return func(s *Stopwatch) { s.window = d }
+}
+
+// Tick records n more processed items, see Rate.
+func (s *Stopwatch) Tick(n int) {
+	if !Enabled {
+		return
+	}
+
+	s.mu.Lock()
+	s.ticks += int64(n)
+	if s.window > 0 {
+		now := s.now()
+		s.tickLog = append(s.tickLog, tick{t: now, n: n})
+		s.pruneTicks(now)
+	}
+	s.mu.Unlock()
+}
+
+// Ticks returns the number of items recorded by Tick in the session.
+func (s *Stopwatch) Ticks() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ticks
+}
+
+// Rate returns the number of items per second recorded by Tick, over the
+// running time of the session. Paused time is excluded.
+func (s *Stopwatch) Rate() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elapsed := s.elapsed()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(s.ticks) / elapsed.Seconds()
+}
+
+// WindowRate returns the number of items per second recorded by Tick within
+// the window set with WithRateWindow, up to now or up to the stop of a
+// stopped stopwatch. A window longer than the elapsed time is shortened to
+// it. It returns zero if no window is set.
+func (s *Stopwatch) WindowRate() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.window <= 0 || s.isReseted() {
+		return 0
+	}
+
+	end := s.now()
+	if s.isStopped() {
+		end = s.stop
+	}
+	s.pruneTicks(end)
+
+	var n int
+	for _, t := range s.tickLog {
+		if !t.t.After(end) {
+			n += t.n
+		}
+	}
+
+	window := s.window
+	if elapsed := s.elapsed(); elapsed < window {
+		window = elapsed
+	}
+	if window <= 0 {
+		return 0
+	}
+	return float64(n) / window.Seconds()
+}
+
+// pruneTicks discards the ticks that are outside of the window ending at t.
+// The lock must be held.
+func (s *Stopwatch) pruneTicks(t time.Time) {
+	i := 0
+	for i < len(s.tickLog) && !s.tickLog[i].t.After(t.Add(-s.window)) {
+		i++
+	}
+	s.tickLog = append(s.tickLog[:0], s.tickLog[i:]...)
+}
+
+// resetRate clears the ticks of the session. The lock must be held.
+func (s *Stopwatch) resetRate() {
+	s.ticks = 0
+	s.tickLog = nil
+}
@@ -0,0 +1,69 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStopwatch_Rate(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+
+	if r := sw.Rate(); r != 0 {
+		t.Errorf("Rate: got: %f expected: 0\n", r)
+	}
+
+	c.Advance(2 * time.Second)
+	sw.Tick(100)
+
+	sw.Stop()
+	c.Advance(time.Minute) // paused time is excluded
+	sw.Start(0)
+
+	c.Advance(2 * time.Second)
+	sw.Tick(100)
+
+	if r := sw.Rate(); r != 50 {
+		t.Errorf("Rate: got: %f expected: 50\n", r)
+	}
+	if n := sw.Ticks(); n != 200 {
+		t.Errorf("Ticks: got: %d expected: 200\n", n)
+	}
+
+	sw.Reset()
+	if n := sw.Ticks(); n != 0 {
+		t.Errorf("Reset: got: %d ticks expected: 0\n", n)
+	}
+}
+
+func TestStopwatch_WindowRate(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c), WithRateWindow(10*time.Second))
+
+	c.Advance(time.Second)
+	sw.Tick(10)
+	c.Advance(time.Second)
+	sw.Tick(10)
+
+	// the window is shortened to the elapsed time
+	if r := sw.WindowRate(); r != 10 {
+		t.Errorf("WindowRate: got: %f expected: 10\n", r)
+	}
+
+	c.Advance(9 * time.Second)
+	sw.Tick(30)
+
+	if r := sw.WindowRate(); r != 4 {
+		t.Errorf("WindowRate: got: %f expected: 4\n", r)
+	}
+	if len(sw.tickLog) != 2 {
+		t.Errorf("WindowRate: got: %d ticks expected the ticks outside of the window to be discarded\n", len(sw.tickLog))
+	}
+
+	if r := Start(0).WindowRate(); r != 0 {
+		t.Errorf("WindowRate: got: %f expected: 0 without a window\n", r)
+	}
+}
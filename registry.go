@@ -0,0 +1,51 @@
+package stopwatch
+
+import (
+	"sort"
+	"sync"
+)
+
+// registry holds the stopwatches registered with Register.
+var registry struct {
+	sync.Mutex
+	watches map[string]*Stopwatch
+}
+
+// Register makes s available under the given name to Handler and Lookup. A
+// stopwatch already registered under the name is replaced.
+func Register(name string, s *Stopwatch) {
+	registry.Lock()
+	if registry.watches == nil {
+		registry.watches = make(map[string]*Stopwatch)
+	}
+	registry.watches[name] = s
+	registry.Unlock()
+}
+
+// Unregister removes the stopwatch registered under the given name.
+func Unregister(name string) {
+	registry.Lock()
+	delete(registry.watches, name)
+	registry.Unlock()
+}
+
+// Lookup returns the stopwatch registered under the given name or nil if
+// there is none.
+func Lookup(name string) *Stopwatch {
+	registry.Lock()
+	defer registry.Unlock()
+	return registry.watches[name]
+}
+
+// registered returns the names of all registered stopwatches, sorted.
+func registered() []string {
+	registry.Lock()
+	defer registry.Unlock()
+
+	names := make([]string, 0, len(registry.watches))
+	for name := range registry.watches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,24 @@
+package stopwatch
+
+import "testing"
+
+func TestRegistry(t *testing.T) {
+	sw := New()
+	Register("registry-test", sw)
+	defer Unregister("registry-test")
+
+	if got := Lookup("registry-test"); got != sw {
+		t.Errorf("Lookup: got: %p expected: %p\n", got, sw)
+	}
+
+	other := New()
+	Register("registry-test", other)
+	if got := Lookup("registry-test"); got != other {
+		t.Errorf("Register: should replace the stopwatch\n")
+	}
+
+	Unregister("registry-test")
+	if got := Lookup("registry-test"); got != nil {
+		t.Errorf("Unregister: got: %p expected: nil\n", got)
+	}
+}
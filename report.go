@@ -0,0 +1,538 @@
+package stopwatch
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// ReportFormat defines the output format of a session report.
+type ReportFormat int
+
+const (
+	ReportCSV ReportFormat = iota
+	ReportJSON
+	ReportMarkdown
+	ReportHTML
+	ReportText
+)
+
+// reportExtensions maps file extensions to report formats.
+var reportExtensions = map[string]ReportFormat{
+	".csv":      ReportCSV,
+	".json":     ReportJSON,
+	".md":       ReportMarkdown,
+	".markdown": ReportMarkdown,
+	".html":     ReportHTML,
+	".htm":      ReportHTML,
+	".txt":      ReportText,
+}
+
+// report is the format independent content of a session report.
+type report struct {
+	Start    time.Time
+	Elapsed  time.Duration
+	Laps     []LapRecord
+	First    int // index of Laps[0], laps dropped by WithMaxLaps come first
+	Sections []reportSection
+	Sessions []Session // only if the stopwatch splits sessions
+	Tags     map[string]string
+	Caller   string // see WithCallers
+
+	format func(time.Duration) string // durations in Markdown and text
+}
+
+// reportSection is a flattened section. Name is the slash separated path of
+// the section, such as "load/parse".
+type reportSection struct {
+	Name    string
+	Depth   int
+	Offset  time.Duration // elapsed time of the session when opened
+	Elapsed time.Duration
+	Caller  string
+}
+
+// report returns the content of the report. Wall clock timestamps are
+// shifted by offset, see WithTimeAuthority.
+func (s *Stopwatch) report(offset time.Duration) *report {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := &report{
+		Start:   s.start,
+		Elapsed: s.elapsed(),
+		Laps:    s.lapRecords(),
+		First:   s.lapStats.Count - len(s.laps),
+		format:  s.formatDuration,
+		Tags:    mergeTags(s.tags, nil),
+		Caller:  s.caller,
+	}
+
+	if s.split != nil {
+		r.Sessions = s.splitSessions(s.split)
+	}
+
+	if offset != 0 {
+		if !r.Start.IsZero() {
+			r.Start = r.Start.Add(offset)
+		}
+		for i := range r.Laps {
+			if !r.Laps[i].Time.IsZero() {
+				r.Laps[i].Time = r.Laps[i].Time.Add(offset)
+			}
+		}
+		for i := range r.Sessions {
+			r.Sessions[i].Start = r.Sessions[i].Start.Add(offset)
+			r.Sessions[i].End = r.Sessions[i].End.Add(offset)
+		}
+	}
+
+	var walk func(prefix string, depth int, sections []*Section)
+	walk = func(prefix string, depth int, sections []*Section) {
+		for _, c := range sections {
+			name := prefix + c.Name
+			r.Sections = append(r.Sections, reportSection{
+				Name:    name,
+				Depth:   depth,
+				Offset:  c.offset,
+				Elapsed: c.Elapsed(),
+				Caller:  c.Caller,
+			})
+			walk(name+"/", depth+1, c.Children)
+		}
+	}
+	walk("", 0, s.sections)
+
+	return r
+}
+
+// Report writes a report of the session, containing the elapsed time, the
+// laps and the sections, in the given format.
+func (s *Stopwatch) Report(w io.Writer, format ReportFormat) error {
+	offset, err := s.anchorOffset()
+	if err != nil {
+		return err
+	}
+
+	r := s.report(offset)
+
+	switch format {
+	case ReportCSV:
+		return r.writeCSV(w)
+	case ReportJSON:
+		return r.writeJSON(w)
+	case ReportMarkdown:
+		return r.writeMarkdown(w)
+	case ReportHTML:
+		return r.writeHTML(w)
+	case ReportText:
+		r.format = s.colorFormat(w)
+		return r.writeText(w)
+	}
+
+	return fmt.Errorf("stopwatch: unknown report format %d", format)
+}
+
+// WriteReportFile writes the session report to the file at path, in the
+// format of its extension (.csv, .json, .md, .html or .txt). A compression
+// extension compresses it, such as "report.json.gz".
+func (s *Stopwatch) WriteReportFile(path string) error {
+	c, name := compressorFor(path)
+	ext := strings.ToLower(filepath.Ext(name))
+	format, ok := reportExtensions[ext]
+	if !ok {
+		return fmt.Errorf("stopwatch: no report format for extension %q", ext)
+	}
+
+	return writeFileAtomic(path, func(w io.Writer) error {
+		return compressTo(w, c, func(w io.Writer) error {
+			return s.Report(w, format)
+		})
+	})
+}
+
+func (r *report) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	row := func(kind, name string, index int, seq uint64, d time.Duration, tags map[string]string, caller string) {
+		i, sq := "", ""
+		if index >= 0 {
+			i, sq = strconv.Itoa(index), strconv.FormatUint(seq, 10)
+		}
+		cw.Write([]string{kind, name, i, sq, strconv.FormatInt(int64(d), 10), d.String(), formatTags(tags), caller})
+	}
+
+	cw.Write([]string{"kind", "name", "index", "seq", "duration_ns", "duration", "tags", "caller"})
+	row("total", "", -1, 0, r.Elapsed, r.Tags, r.Caller)
+	for i, lap := range r.Laps {
+		row("lap", "", r.First+i, lap.Seq, lap.Duration, lap.Tags, lap.Caller)
+	}
+	for _, c := range r.Sections {
+		row("section", c.Name, -1, 0, c.Elapsed, nil, c.Caller)
+	}
+	for _, ss := range r.Sessions {
+		row("session", ss.Start.Format(time.RFC3339), -1, 0, ss.Elapsed, nil, "")
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func (r *report) writeJSON(w io.Writer) error {
+	type duration struct {
+		Name     string            `json:"name,omitempty"`
+		Seq      uint64            `json:"seq,omitempty"`
+		Time     *time.Time        `json:"time,omitempty"`
+		Duration string            `json:"duration"`
+		Nanos    int64             `json:"duration_ns"`
+		Tags     map[string]string `json:"tags,omitempty"`
+		Caller   string            `json:"caller,omitempty"`
+	}
+
+	type session struct {
+		Start    time.Time `json:"start"`
+		End      time.Time `json:"end"`
+		Duration string    `json:"duration"`
+		Nanos    int64     `json:"duration_ns"`
+	}
+
+	out := struct {
+		Start    *time.Time        `json:"start,omitempty"`
+		Tags     map[string]string `json:"tags,omitempty"`
+		Elapsed  duration          `json:"elapsed"`
+		Laps     []duration        `json:"laps"`
+		Sections []duration        `json:"sections"`
+		Sessions []session         `json:"sessions,omitempty"`
+	}{
+		Tags:     r.Tags,
+		Elapsed:  duration{Duration: r.Elapsed.String(), Nanos: int64(r.Elapsed), Caller: r.Caller},
+		Laps:     make([]duration, 0, len(r.Laps)),
+		Sections: make([]duration, 0, len(r.Sections)),
+	}
+
+	if !r.Start.IsZero() {
+		out.Start = &r.Start
+	}
+	for _, lap := range r.Laps {
+		d := duration{Seq: lap.Seq, Duration: lap.Duration.String(), Nanos: int64(lap.Duration), Tags: lap.Tags, Caller: lap.Caller}
+		if !lap.Time.IsZero() {
+			t := lap.Time
+			d.Time = &t
+		}
+		out.Laps = append(out.Laps, d)
+	}
+	for _, c := range r.Sections {
+		out.Sections = append(out.Sections, duration{Name: c.Name, Duration: c.Elapsed.String(), Nanos: int64(c.Elapsed), Caller: c.Caller})
+	}
+	for _, ss := range r.Sessions {
+		out.Sessions = append(out.Sessions, session{ss.Start, ss.End, ss.Elapsed.String(), int64(ss.Elapsed)})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func (r *report) writeMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "**Elapsed:** %s\n", r.format(r.Elapsed))
+	if r.Caller != "" {
+		fmt.Fprintf(&b, "\n**Started at:** %s\n", r.Caller)
+	}
+	if len(r.Tags) > 0 {
+		fmt.Fprintf(&b, "\n**Tags:** %s\n", strings.Replace(formatTags(r.Tags), "|", `\|`, -1))
+	}
+
+	if len(r.Laps) > 0 {
+		b.WriteString("\n| Lap | Duration |\n| ---: | ---: |\n")
+		for i, lap := range r.Laps {
+			fmt.Fprintf(&b, "| %d | %s |\n", r.First+i+1, r.format(lap.Duration))
+		}
+
+		min, max, avg := r.lapSummary()
+		fmt.Fprintf(&b, "\n**Laps:** min %s, max %s, avg %s\n", r.format(min), r.format(max), r.format(avg))
+	}
+
+	if len(r.Sections) > 0 {
+		b.WriteString("\n| Section | Duration |\n| --- | ---: |\n")
+		for _, c := range r.Sections {
+			fmt.Fprintf(&b, "| %s | %s |\n", strings.Replace(c.Name, "|", `\|`, -1), r.format(c.Elapsed))
+		}
+	}
+
+	if len(r.Sessions) > 0 {
+		b.WriteString("\n| Session | Start | End | Duration |\n| ---: | --- | --- | ---: |\n")
+		for i, ss := range r.Sessions {
+			fmt.Fprintf(&b, "| %d | %s | %s | %s |\n", i+1,
+				ss.Start.Format(time.RFC3339), ss.End.Format(time.RFC3339), r.format(ss.Elapsed))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (r *report) writeText(w io.Writer) error {
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Elapsed: %s\n", r.format(r.Elapsed))
+	if r.Caller != "" {
+		fmt.Fprintf(tw, "Started at: %s\n", r.Caller)
+	}
+	if len(r.Tags) > 0 {
+		fmt.Fprintf(tw, "Tags: %s\n", formatTags(r.Tags))
+	}
+
+	if len(r.Laps) > 0 {
+		fmt.Fprint(tw, "\nLap\tDuration\n")
+		for i, lap := range r.Laps {
+			fmt.Fprintf(tw, "%d\t%s\n", r.First+i+1, r.format(lap.Duration))
+		}
+
+		min, max, avg := r.lapSummary()
+		fmt.Fprintf(tw, "min %s, max %s, avg %s\n", r.format(min), r.format(max), r.format(avg))
+	}
+
+	if len(r.Sections) > 0 {
+		fmt.Fprint(tw, "\nSection\tDuration\n")
+		for _, c := range r.Sections {
+			fmt.Fprintf(tw, "%s\t%s\n", c.Name, r.format(c.Elapsed))
+		}
+	}
+
+	if len(r.Sessions) > 0 {
+		fmt.Fprint(tw, "\nSession\tStart\tEnd\tDuration\n")
+		for i, ss := range r.Sessions {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", i+1,
+				ss.Start.Format(time.RFC3339), ss.End.Format(time.RFC3339), r.format(ss.Elapsed))
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// lapSummary returns the shortest, the longest and the average lap.
+func (r *report) lapSummary() (min, max, avg time.Duration) {
+	var total time.Duration
+	for i, lap := range r.Laps {
+		if i == 0 || lap.Duration < min {
+			min = lap.Duration
+		}
+		if lap.Duration > max {
+			max = lap.Duration
+		}
+		total += lap.Duration
+	}
+
+	if len(r.Laps) > 0 {
+		avg = total / time.Duration(len(r.Laps))
+	}
+	return min, max, avg
+}
+
+var reportHTML = template.Must(template.New("report").Funcs(template.FuncMap{
+	"inc":        func(i int) int { return i + 1 },
+	"add":        func(a, b int) int { return a + b },
+	"formatTags": formatTags,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Stopwatch report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 12px; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+canvas { display: block; margin-bottom: 2em; border: 1px solid #eee; }
+</style>
+</head>
+<body>
+<h1>Stopwatch report</h1>
+<p>Elapsed: <strong>{{.Elapsed}}</strong></p>
+{{- if .Caller}}
+<p>Started at: {{.Caller}}</p>
+{{- end}}
+{{- if .Tags}}
+<p>Tags: {{formatTags .Tags}}</p>
+{{- end}}
+<h2>Timeline</h2>
+<canvas id="timeline" width="900" height="{{.TimelineHeight}}"></canvas>
+{{- if .Laps}}
+<h2>Laps</h2>
+<canvas id="laps" width="900" height="200"></canvas>
+<h2>Lap histogram</h2>
+<canvas id="histogram" width="900" height="200"></canvas>
+<table>
+<tr><th>Lap</th><th>Duration</th>{{if .LapTags}}<th>Tags</th>{{end}}{{if .Callers}}<th>Caller</th>{{end}}</tr>
+{{- range $i, $lap := .Laps}}
+<tr><td>{{inc (add $.First $i)}}</td><td>{{$lap.Duration}}</td>{{if $.LapTags}}<td>{{formatTags $lap.Tags}}</td>{{end}}{{if $.Callers}}<td>{{$lap.Caller}}</td>{{end}}</tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Sections}}
+<h2>Sections</h2>
+<table>
+<tr><th>Section</th><th>Duration</th>{{if .Callers}}<th>Caller</th>{{end}}</tr>
+{{- range .Sections}}
+<tr><td>{{.Name}}</td><td>{{.Elapsed}}</td>{{if $.Callers}}<td>{{.Caller}}</td>{{end}}</tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Sessions}}
+<h2>Sessions</h2>
+<table>
+<tr><th>Session</th><th>Start</th><th>End</th><th>Duration</th></tr>
+{{- range $i, $s := .Sessions}}
+<tr><td>{{inc $i}}</td><td>{{$s.Start.Format "2006-01-02 15:04:05"}}</td><td>{{$s.End.Format "2006-01-02 15:04:05"}}</td><td>{{$s.Elapsed}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+<script>
+(function() {
+var data = {{.Chart}};
+var colors = ["#4e79a7", "#f28e2b", "#59a14f", "#e15759", "#76b7b2", "#edc948"];
+
+function ms(v) { return v < 1 ? (v * 1000).toFixed(0) + "µs" : v.toFixed(v < 10 ? 2 : 0) + "ms"; }
+
+function canvas(id) {
+	var c = document.getElementById(id);
+	if (!c) { return null; }
+	var ctx = c.getContext("2d");
+	ctx.font = "11px sans-serif";
+	ctx.textBaseline = "middle";
+	return { ctx: ctx, w: c.width, h: c.height };
+}
+
+function bars(id, values, labels) {
+	var v = canvas(id);
+	if (!v || !values.length) { return; }
+	var max = Math.max.apply(null, values) || 1;
+	var left = 50, bottom = 20, bw = (v.w - left) / values.length;
+	v.ctx.fillStyle = "#333";
+	v.ctx.fillText(labels.max, 0, 8);
+	v.ctx.fillText("0", 0, v.h - bottom);
+	for (var i = 0; i < values.length; i++) {
+		var bh = (v.h - bottom - 10) * values[i] / max;
+		v.ctx.fillStyle = colors[0];
+		v.ctx.fillRect(left + i * bw + 1, v.h - bottom - bh, Math.max(bw - 2, 1), bh);
+		if (labels.x && values.length <= 30) {
+			v.ctx.fillStyle = "#333";
+			v.ctx.fillText(labels.x(i), left + i * bw + 2, v.h - bottom / 2);
+		}
+	}
+}
+
+function timeline() {
+	var v = canvas("timeline");
+	var total = data.elapsed;
+	data.sections.forEach(function(s) { total = Math.max(total, s.offset + s.elapsed); });
+	if (!v || total <= 0) { return; }
+	var left = 50, scale = (v.w - left - 10) / total, row = 24;
+	v.ctx.fillStyle = "#333";
+	v.ctx.fillText("laps", 0, row / 2);
+	v.ctx.fillText(ms(total), v.w - 60, v.h - 8);
+	var offset = 0;
+	data.laps.forEach(function(lap, i) {
+		v.ctx.fillStyle = colors[(data.first + i) % 2];
+		v.ctx.fillRect(left + offset * scale, 2, Math.max(lap * scale, 1), row - 4);
+		offset += lap;
+	});
+	data.sections.forEach(function(s) {
+		var y = (s.depth + 1) * row;
+		var w = Math.max(s.elapsed * scale, 1);
+		v.ctx.fillStyle = colors[(s.depth + 2) % colors.length];
+		v.ctx.fillRect(left + s.offset * scale, y + 2, w, row - 4);
+		v.ctx.fillStyle = "#fff";
+		if (w > 40) { v.ctx.fillText(s.name, left + s.offset * scale + 4, y + row / 2); }
+	});
+}
+
+function histogram() {
+	if (!data.laps.length) { return; }
+	var min = Math.min.apply(null, data.laps), max = Math.max.apply(null, data.laps);
+	var n = Math.min(20, data.laps.length), width = (max - min) / n || 1, counts = [];
+	for (var i = 0; i < n; i++) { counts.push(0); }
+	data.laps.forEach(function(lap) { counts[Math.min(Math.floor((lap - min) / width), n - 1)]++; });
+	bars("histogram", counts, {
+		max: Math.max.apply(null, counts) + " laps",
+		x: function(i) { return ms(min + i * width); }
+	});
+}
+
+timeline();
+bars("laps", data.laps, {
+	max: ms(Math.max.apply(null, data.laps)),
+	x: function(i) { return String(data.first + i + 1); }
+});
+histogram();
+})();
+</script>
+</body>
+</html>
+`))
+
+// reportChart is the data embedded into the HTML report for drawing charts.
+// All durations are in milliseconds.
+type reportChart struct {
+	Elapsed  float64              `json:"elapsed"`
+	First    int                  `json:"first"`
+	Laps     []float64            `json:"laps"`
+	Sections []reportChartSection `json:"sections"`
+}
+
+type reportChartSection struct {
+	Name    string  `json:"name"`
+	Depth   int     `json:"depth"`
+	Offset  float64 `json:"offset"`
+	Elapsed float64 `json:"elapsed"`
+}
+
+func (r *report) writeHTML(w io.Writer) error {
+	millis := func(d time.Duration) float64 { return float64(d) / float64(time.Millisecond) }
+
+	chart := reportChart{
+		Elapsed:  millis(r.Elapsed),
+		First:    r.First,
+		Laps:     make([]float64, 0, len(r.Laps)),
+		Sections: make([]reportChartSection, 0, len(r.Sections)),
+	}
+
+	depth, lapTags := 0, false
+	for _, lap := range r.Laps {
+		chart.Laps = append(chart.Laps, millis(lap.Duration))
+		lapTags = lapTags || len(lap.Tags) > 0
+	}
+	for _, c := range r.Sections {
+		chart.Sections = append(chart.Sections, reportChartSection{
+			Name:    c.Name,
+			Depth:   c.Depth,
+			Offset:  millis(c.Offset),
+			Elapsed: millis(c.Elapsed),
+		})
+		if c.Depth+1 > depth {
+			depth = c.Depth + 1
+		}
+	}
+
+	return reportHTML.Execute(w, struct {
+		*report
+		Chart          reportChart
+		TimelineHeight int
+		LapTags        bool
+		Callers        bool
+	}{r, chart, (depth+1)*24 + 16, lapTags, r.Caller != ""})
+}
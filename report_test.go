@@ -0,0 +1,213 @@
+package stopwatch
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// reportStopwatch returns a stopped stopwatch with fixed laps and sections.
+func reportStopwatch() *Stopwatch {
+	now := time.Now()
+	sw := &Stopwatch{
+		start:   now.Add(-time.Second),
+		stop:    now,
+		stopped: true,
+		laps:    []LapRecord{{Seq: 1, Duration: 300 * time.Millisecond}, {Seq: 2, Duration: 700 * time.Millisecond}},
+	}
+	sw.lapStats.Count = len(sw.laps)
+
+	load := &Section{Name: "load", Start: now.Add(-time.Second), End: now}
+	load.Children = []*Section{
+		{Name: "parse", Start: now.Add(-time.Second), End: now.Add(-500 * time.Millisecond), parent: load},
+	}
+	sw.sections = []*Section{load}
+
+	return sw
+}
+
+func TestStopwatch_ReportCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := reportStopwatch().Report(&buf, ReportCSV); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	if len(records) != 6 {
+		t.Fatalf("Report: got: %d rows expected: %d\n", len(records), 6)
+	}
+
+	if records[2][3] != "1" || records[3][3] != "2" {
+		t.Errorf("Report: unexpected lap sequence numbers %v %v\n", records[2], records[3])
+	}
+
+	if records[5][0] != "section" || records[5][1] != "load/parse" || records[5][5] != "500ms" {
+		t.Errorf("Report: unexpected section row %v\n", records[5])
+	}
+}
+
+func TestStopwatch_ReportTags(t *testing.T) {
+	sw := reportStopwatch()
+	sw.tags = map[string]string{"job": "a<b"}
+	sw.laps[1].Tags = map[string]string{"stage": "parse"}
+
+	var buf bytes.Buffer
+	if err := sw.Report(&buf, ReportCSV); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	if records[0][6] != "tags" || records[1][6] != "job=a<b" || records[2][6] != "" || records[3][6] != "stage=parse" {
+		t.Errorf("Report: unexpected csv tags %v\n", records)
+	}
+
+	buf.Reset()
+	if err := sw.Report(&buf, ReportHTML); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	if !strings.Contains(buf.String(), "<p>Tags: job=a&lt;b</p>") ||
+		!strings.Contains(buf.String(), "<td>2</td><td>700ms</td><td>stage=parse</td>") {
+		t.Errorf("Report: html without tags:\n%s\n", buf.String())
+	}
+}
+
+func TestStopwatch_ReportCallers(t *testing.T) {
+	sw := reportStopwatch()
+	sw.caller = "main.go:10"
+	sw.laps[0].Caller = "main.go:11"
+	sw.sections[0].Caller = "main.go:12"
+
+	var buf bytes.Buffer
+	if err := sw.Report(&buf, ReportCSV); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	if records[0][7] != "caller" || records[1][7] != "main.go:10" || records[2][7] != "main.go:11" || records[4][7] != "main.go:12" {
+		t.Errorf("Report: unexpected csv callers %v\n", records)
+	}
+
+	buf.Reset()
+	if err := sw.Report(&buf, ReportHTML); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	for _, s := range []string{"<p>Started at: main.go:10</p>", "<td>1</td><td>300ms</td><td>main.go:11</td>", "<td>load</td><td>1s</td><td>main.go:12</td>"} {
+		if !strings.Contains(buf.String(), s) {
+			t.Errorf("Report: html without %s:\n%s\n", s, buf.String())
+		}
+	}
+}
+
+func TestStopwatch_ReportJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := reportStopwatch().Report(&buf, ReportJSON); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	var v struct {
+		Elapsed struct {
+			Nanos int64 `json:"duration_ns"`
+		} `json:"elapsed"`
+		Laps     []json.RawMessage `json:"laps"`
+		Sections []json.RawMessage `json:"sections"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	if time.Duration(v.Elapsed.Nanos) != time.Second || len(v.Laps) != 2 || len(v.Sections) != 2 {
+		t.Errorf("Report: unexpected json %s\n", buf.String())
+	}
+}
+
+func TestStopwatch_ReportMarkdownHTML(t *testing.T) {
+	var md, html bytes.Buffer
+	sw := reportStopwatch()
+
+	if err := sw.Report(&md, ReportMarkdown); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	if !strings.Contains(md.String(), "| 2 | 700ms |") || !strings.Contains(md.String(), "| load/parse | 500ms |") ||
+		!strings.Contains(md.String(), "**Laps:** min 300ms, max 700ms, avg 500ms") {
+		t.Errorf("Report: unexpected markdown:\n%s\n", md.String())
+	}
+
+	if err := sw.Report(&html, ReportHTML); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	if !strings.Contains(html.String(), "<td>load/parse</td><td>500ms</td>") {
+		t.Errorf("Report: unexpected html:\n%s\n", html.String())
+	}
+
+	if !strings.Contains(html.String(), `var data = {"elapsed":1000,"first":0,"laps":[300,700],`) {
+		t.Errorf("Report: chart data is not embedded:\n%s\n", html.String())
+	}
+
+	if err := sw.Report(&html, ReportFormat(-1)); err == nil {
+		t.Error("Report: an unknown format should return an error")
+	}
+}
+
+func TestStopwatch_ReportText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := reportStopwatch().Report(&buf, ReportText); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	expected := `Elapsed: 1s
+
+Lap  Duration
+1    300ms
+2    700ms
+min 300ms, max 700ms, avg 500ms
+
+Section     Duration
+load        1s
+load/parse  500ms
+`
+	if buf.String() != expected {
+		t.Errorf("Report: got:\n%s\nexpected:\n%s\n", buf.String(), expected)
+	}
+}
+
+func TestStopwatch_WriteReportFile(t *testing.T) {
+	dir := t.TempDir()
+	sw := reportStopwatch()
+
+	for _, name := range []string{"report.csv", "report.json", "report.md", "report.html", "report.txt"} {
+		path := filepath.Join(dir, name)
+		if err := sw.WriteReportFile(path); err != nil {
+			t.Fatalf("error: %s\n", err)
+		}
+
+		if fi, err := os.Stat(path); err != nil || fi.Size() == 0 {
+			t.Errorf("WriteReportFile: %s was not written\n", name)
+		}
+	}
+
+	if err := sw.WriteReportFile(filepath.Join(dir, "report.pdf")); err == nil {
+		t.Error("WriteReportFile: an unknown extension should return an error")
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 5 {
+		t.Errorf("WriteReportFile: temporary files left behind: %d entries\n", len(entries))
+	}
+}
@@ -0,0 +1,75 @@
+package stopwatch
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy defines how TimedRetry retries a failing function.
+type RetryPolicy struct {
+	Attempts   int           // maximum number of attempts, at least one
+	Backoff    time.Duration // sleep before the second attempt
+	Multiplier float64       // growth of the backoff per attempt, 1 if zero
+	MaxBackoff time.Duration // upper bound of the backoff, zero for none
+
+	// Retry reports whether the error of an attempt is worth retrying. All
+	// errors are retried if it is nil.
+	Retry func(err error) bool
+}
+
+// backoff returns the sleep before the given attempt, starting at 2.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	m := p.Multiplier
+	if m == 0 {
+		m = 1
+	}
+
+	d := float64(p.Backoff)
+	for i := 2; i < attempt; i++ {
+		d *= m
+	}
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		return p.MaxBackoff
+	}
+	return time.Duration(d)
+}
+
+// TimedRetry calls fn until it succeeds, the attempts of the policy are used
+// up, its error is not worth retrying or ctx is done. It returns a stopped
+// stopwatch and the latest error. Every attempt is a lap tagged with
+// "attempt", its number starting at 1, and "outcome", either "ok" or
+// "error". The backoff sleeps between attempts are laps tagged with
+// "backoff", so the elapsed time is the total time of the retries. The
+// context passed to fn carries the stopwatch, see FromContext.
+// Example : s, err := stopwatch.TimedRetry(ctx, fetch, stopwatch.RetryPolicy{Attempts: 3, Backoff: 100 * time.Millisecond})
+func TimedRetry(ctx context.Context, fn Func, policy RetryPolicy, opts ...Option) (*Stopwatch, error) {
+	s := Start(0, opts...)
+	defer s.Stop()
+	ctx = NewContext(ctx, s)
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn(ctx)
+
+		outcome := "ok"
+		if err != nil {
+			outcome = "error"
+		}
+		s.LapWithTags(map[string]string{"attempt": strconv.Itoa(attempt), "outcome": outcome})
+
+		if err == nil || attempt >= policy.Attempts || (policy.Retry != nil && !policy.Retry(err)) {
+			return s, err
+		}
+
+		timer := time.NewTimer(policy.backoff(attempt + 1))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s.LapWithTags(map[string]string{"backoff": strconv.Itoa(attempt)})
+			return s, err
+		case <-timer.C:
+		}
+		s.LapWithTags(map[string]string{"backoff": strconv.Itoa(attempt)})
+	}
+}
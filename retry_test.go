@@ -0,0 +1,71 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimedRetry(t *testing.T) {
+	c := newClock()
+	calls := 0
+	fn := func(ctx context.Context) error {
+		calls++
+		c.Advance(time.Duration(calls) * time.Second)
+		if FromContext(ctx) == nil {
+			t.Error("TimedRetry: the context should carry the stopwatch")
+		}
+		if calls < 3 {
+			return errors.New("unavailable")
+		}
+		return nil
+	}
+
+	sw, err := TimedRetry(context.Background(), fn, RetryPolicy{Attempts: 5, Backoff: time.Millisecond}, WithClock(c))
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	if !sw.IsStopped() || sw.ElapsedTime() != 6*time.Second {
+		t.Errorf("TimedRetry: got elapsed: %s expected: 6s\n", sw.ElapsedTime())
+	}
+
+	expected := []string{
+		"attempt=1, outcome=error", "backoff=1",
+		"attempt=2, outcome=error", "backoff=2",
+		"attempt=3, outcome=ok",
+	}
+	laps := sw.LapRecords()
+	if len(laps) != len(expected) {
+		t.Fatalf("TimedRetry: got: %d laps expected: %d\n", len(laps), len(expected))
+	}
+	for i, lap := range laps {
+		if got := formatTags(lap.Tags); got != expected[i] {
+			t.Errorf("TimedRetry: lap %d got: %s expected: %s\n", i, got, expected[i])
+		}
+	}
+
+	// errors not worth retrying end the retries
+	calls = 0
+	fatal := errors.New("fatal")
+	_, err = TimedRetry(context.Background(), func(ctx context.Context) error {
+		calls++
+		return fatal
+	}, RetryPolicy{Attempts: 5, Retry: func(err error) bool { return err != fatal }})
+	if err != fatal || calls != 1 {
+		t.Errorf("TimedRetry: got: %d calls expected: 1\n", calls)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{Backoff: time.Second, Multiplier: 2, MaxBackoff: 3 * time.Second}
+	expected := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}
+	for i, d := range expected {
+		if got := p.backoff(i + 2); got != d {
+			t.Errorf("backoff: attempt %d got: %s expected: %s\n", i+2, got, d)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package stopwatch
+
+import (
+	"context"
+	"runtime/trace"
+)
+
+// WithTrace makes the stopwatch visible in "go tool trace" timelines. Every
+// running period, from Start to Stop, is a runtime/trace task of the type
+// "stopwatch". Within it every lap is a region named "lap" and every section
+// a region named by its path, such as "load/parse". Regions have to be ended
+// by the goroutine that started them, so laps and sections should be driven
+// by a single goroutine.
+func WithTrace() Option {
+	return func(s *Stopwatch) { s.trace = true }
+}
+
+// startTrace starts a new task and its first lap region, ending the current
+// task if any. The lock must be held.
+func (s *Stopwatch) startTrace() {
+	if !s.trace {
+		return
+	}
+
+	s.endTrace()
+	s.traceCtx, s.task = trace.NewTask(context.Background(), "stopwatch")
+	s.lapRegion = trace.StartRegion(s.traceCtx, "lap")
+}
+
+// endTrace ends the current task and lap region. The lock must be held.
+func (s *Stopwatch) endTrace() {
+	if s.task == nil {
+		return
+	}
+
+	s.lapRegion.End()
+	s.task.End()
+	s.traceCtx, s.task, s.lapRegion = nil, nil, nil
+}
+
+// lapTrace ends the current lap region and starts the next one. The lock
+// must be held.
+func (s *Stopwatch) lapTrace() {
+	if s.task == nil {
+		return
+	}
+
+	s.lapRegion.End()
+	s.lapRegion = trace.StartRegion(s.traceCtx, "lap")
+}
+
+// sectionTrace starts the region of the section c if there is a task. The
+// lock must be held.
+func (s *Stopwatch) sectionTrace(c *Section) {
+	if s.task != nil {
+		c.region = trace.StartRegion(s.traceCtx, c.path())
+	}
+}
@@ -0,0 +1,38 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bytes"
+	"runtime/trace"
+	"testing"
+)
+
+func TestStopwatch_WithTrace(t *testing.T) {
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Skipf("tracing is not available: %s", err)
+	}
+
+	sw := Start(0, WithTrace())
+	end := sw.Section("load")
+	sw.Section("traced-parse")()
+	end()
+	sw.Lap()
+
+	if sw.task == nil || sw.lapRegion == nil {
+		t.Error("Start: expected a running task and lap region")
+	}
+
+	sw.Stop()
+	trace.Stop()
+
+	if sw.task != nil || sw.lapRegion != nil {
+		t.Error("Stop: expected the task and lap region to be ended")
+	}
+
+	if !bytes.Contains(buf.Bytes(), []byte("load/traced-parse")) {
+		t.Error("WithTrace: expected the section region in the trace")
+	}
+}
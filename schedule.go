@@ -0,0 +1,112 @@
+package stopwatch
+
+import "time"
+
+// scheduled is the action of a timer of StartAt or StopAt.
+type scheduled int
+
+const (
+	scheduledStart scheduled = iota
+	scheduledStop
+)
+
+// StartAt schedules Start at the wall clock time t, such as the top of the
+// minute. The session of a reseted stopwatch starts at t, even if the timer
+// fires slightly late. A time in the past starts the stopwatch right away.
+// The returned function cancels the scheduled start, it reports whether the
+// start was prevented. See also CancelPendingStart.
+func (s *Stopwatch) StartAt(t time.Time) (cancel func() bool) {
+	return s.schedule(t, scheduledStart, func() { s.Start(t.Sub(s.now())) })
+}
+
+// StopAt schedules Stop at the wall clock time t. A time in the past stops
+// the stopwatch right away. The returned function cancels the scheduled stop,
+// it reports whether the stop was prevented.
+func (s *Stopwatch) StopAt(t time.Time) (cancel func() bool) {
+	return s.schedule(t, scheduledStop, s.Stop)
+}
+
+// schedule calls fn at t with a timer tracked by the stopwatch, so it can be
+// canceled with the stopwatch, see CancelPendingStart and Pool.
+func (s *Stopwatch) schedule(t time.Time, kind scheduled, fn func()) (cancel func() bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var timer *time.Timer
+	gen := s.gen
+	timer = time.AfterFunc(t.Sub(s.now()), func() {
+		s.mu.Lock()
+		_, ok := s.timers[timer]
+		ok = ok && s.gen == gen // not put into a pool since
+		delete(s.timers, timer)
+		s.mu.Unlock()
+
+		if ok {
+			fn()
+		}
+	})
+
+	if s.timers == nil {
+		s.timers = make(map[*time.Timer]scheduled)
+	}
+	s.timers[timer] = kind
+
+	return func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.timers, timer)
+		return timer.Stop()
+	}
+}
+
+// Pending reports whether a start is pending. That is a start scheduled with
+// StartAt or the countdown of a session started with a positive offset, such
+// as Start(2 * time.Second). The elapsed time of a counting down stopwatch is
+// negative.
+func (s *Stopwatch) Pending() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pendingStarts() > 0 || s.isCountingDown()
+}
+
+// CancelPendingStart cancels all starts scheduled with StartAt and resets a
+// counting down stopwatch, so it doesn't start at all. It reports whether
+// there was a pending start.
+func (s *Stopwatch) CancelPendingStart() bool {
+	s.mu.Lock()
+
+	pending := s.pendingStarts() > 0
+	for timer, kind := range s.timers {
+		if kind == scheduledStart {
+			timer.Stop()
+			delete(s.timers, timer)
+		}
+	}
+
+	if !s.isCountingDown() {
+		s.mu.Unlock()
+		return pending
+	}
+
+	s.mu.Unlock()
+	s.Reset()
+	return true
+}
+
+// pendingStarts returns the number of starts scheduled with StartAt. The lock
+// must be held.
+func (s *Stopwatch) pendingStarts() int {
+	n := 0
+	for _, kind := range s.timers {
+		if kind == scheduledStart {
+			n++
+		}
+	}
+	return n
+}
+
+// isCountingDown reports whether the session starts in the future. The lock
+// must be held.
+func (s *Stopwatch) isCountingDown() bool {
+	return s.isRunning() && s.start.After(s.now())
+}
@@ -0,0 +1,83 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStopwatch_StartAtStopAt(t *testing.T) {
+	sw := New()
+
+	start := time.Now().Add(20 * time.Millisecond)
+	sw.StartAt(start)
+	sw.StopAt(start.Add(20 * time.Millisecond))
+
+	if !sw.IsReseted() {
+		t.Error("StartAt: the stopwatch should not start before the time")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if sw.IsReseted() || sw.IsStopped() {
+		t.Error("StartAt: the stopwatch should be running")
+	}
+	if d := sw.start.Sub(start); d < -time.Millisecond || d > time.Millisecond {
+		t.Errorf("StartAt: got start: %s expected: %s\n", sw.start, start)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if !sw.IsStopped() {
+		t.Error("StopAt: the stopwatch should be stopped")
+	}
+}
+
+func TestStopwatch_StartAtCancel(t *testing.T) {
+	sw := New()
+
+	cancel := sw.StartAt(time.Now().Add(10 * time.Millisecond))
+	if !cancel() {
+		t.Error("StartAt: cancel should report that the start was prevented")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if !sw.IsReseted() {
+		t.Error("StartAt: a canceled start should not start the stopwatch")
+	}
+}
+
+func TestStopwatch_CancelPendingStart(t *testing.T) {
+	sw := New()
+	if sw.Pending() || sw.CancelPendingStart() {
+		t.Error("Pending: a new stopwatch has no pending start")
+	}
+
+	sw.StartAt(time.Now().Add(10 * time.Millisecond))
+	if !sw.Pending() {
+		t.Error("Pending: expected a start scheduled with StartAt")
+	}
+	if !sw.CancelPendingStart() || sw.Pending() {
+		t.Error("CancelPendingStart: expected the scheduled start to be canceled")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if !sw.IsReseted() {
+		t.Error("CancelPendingStart: the stopwatch should not start")
+	}
+
+	c := newClock()
+	sw = Start(time.Second, WithClock(c))
+	if !sw.Pending() {
+		t.Error("Pending: expected a counting down stopwatch")
+	}
+	if !sw.CancelPendingStart() || !sw.IsReseted() {
+		t.Error("CancelPendingStart: expected the countdown to be reseted")
+	}
+
+	sw = Start(time.Second, WithClock(c))
+	c.Advance(2 * time.Second)
+	if sw.Pending() || sw.CancelPendingStart() {
+		t.Error("Pending: a started stopwatch has no pending start")
+	}
+}
@@ -0,0 +1,134 @@
+package stopwatch
+
+import (
+	"runtime/trace"
+	"time"
+)
+
+// Section is a named region inside a stopwatch session. Sections opened while
+// another section is still open become its children.
+type Section struct {
+	Name       string
+	Start, End time.Time
+	Children   []*Section
+	Caller     string // file:line at which the section was opened, see WithCallers
+
+	parent *Section
+	offset time.Duration // elapsed time of the stopwatch when opened
+	clock  Clock
+	region *trace.Region // see WithTrace
+}
+
+// IsOpen shows whether the section is still open or not.
+func (c *Section) IsOpen() bool { return c.End.IsZero() }
+
+// Elapsed returns the duration of the section. An open section returns the
+// duration since it was opened.
+func (c *Section) Elapsed() time.Duration {
+	if c.IsOpen() {
+		return clockOrSystem(c.clock).Now().Sub(c.Start)
+	}
+
+	return c.End.Sub(c.Start)
+}
+
+// path returns the slash separated names from the top level section to c.
+func (c *Section) path() string {
+	if c.parent == nil {
+		return c.Name
+	}
+	return c.parent.path() + "/" + c.Name
+}
+
+// Section opens a new named section and returns a function that closes it.
+// Closing a section also closes all of its open children.
+// Useful to use with a defer statement.
+// Example : defer s.Section("parse")()
+func (s *Stopwatch) Section(name string) func() {
+	if !Enabled {
+		return func() {}
+	}
+
+	c := s.openSection(name, s.callerOf())
+	return func() { s.endSection(c) }
+}
+
+// openSection opens a new named section, caller is its call site.
+func (s *Stopwatch) openSection(name, caller string) *Section {
+	s.mu.Lock()
+	c := &Section{Name: name, Start: s.now(), Caller: caller, parent: s.section, offset: s.elapsed(), clock: s.clock}
+	if s.section != nil {
+		s.section.Children = append(s.section.Children, c)
+	} else {
+		s.sections = append(s.sections, c)
+	}
+	s.section = c
+	s.sectionTrace(c)
+
+	e := s.event(EventSectionStart)
+	e.Section = c.path()
+	s.unlock(e)
+
+	return c
+}
+
+// Sections returns a copy of all top level sections and their children.
+func (s *Stopwatch) Sections() []*Section {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copySections(s.sections, nil)
+}
+
+func copySections(sections []*Section, parent *Section) []*Section {
+	out := make([]*Section, len(sections))
+	for i, c := range sections {
+		cp := *c
+		cp.parent = parent
+		cp.Children = copySections(c.Children, &cp)
+		out[i] = &cp
+	}
+	return out
+}
+
+// endSection closes c and its open children. Sections that are already
+// closed or belong to a previous session are ignored.
+func (s *Stopwatch) endSection(c *Section) {
+	s.mu.Lock()
+
+	open := false
+	for p := s.section; p != nil; p = p.parent {
+		if p == c {
+			open = true
+			break
+		}
+	}
+
+	if !open {
+		s.mu.Unlock()
+		return
+	}
+
+	var events []Event
+	now := s.now()
+	for {
+		p := s.section
+		p.End = now
+		s.section = p.parent
+		if p.region != nil {
+			p.region.End()
+		}
+
+		e := s.event(EventSectionEnd)
+		e.Section = p.path()
+		e.Duration = p.Elapsed()
+		events = append(events, e)
+		events = s.checkBudget(events, p)
+
+		if p == c {
+			break
+		}
+	}
+	events = s.checkDeadline(events)
+
+	s.unlock(events...)
+}
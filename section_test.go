@@ -0,0 +1,74 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStopwatch_Section(t *testing.T) {
+	sw := Start(0)
+
+	endLoad := sw.Section("load")
+	endParse := sw.Section("parse")
+	time.Sleep(time.Millisecond * 20)
+	endParse()
+	sw.Section("render")
+	time.Sleep(time.Millisecond * 10)
+	endLoad() // closes render as well
+
+	sections := sw.Sections()
+	if len(sections) != 1 || sections[0].Name != "load" {
+		t.Fatalf("Section: got: %v expected a single load section\n", sections)
+	}
+
+	children := sections[0].Children
+	if len(children) != 2 || children[0].Name != "parse" || children[1].Name != "render" {
+		t.Fatalf("Section: unexpected children %v\n", children)
+	}
+
+	for _, c := range append(children, sections[0]) {
+		if c.IsOpen() {
+			t.Errorf("Section: %s should be closed\n", c.Name)
+		}
+	}
+
+	ms := int(RoundFloat(float64(children[0].Elapsed()/time.Millisecond), 0))
+	if ms != 20 {
+		t.Errorf("Section: got: %d expected: %d\n", ms, 20)
+	}
+
+	if sw.section != nil {
+		t.Error("Section: no section should be open")
+	}
+
+	end := sw.Section("stale")
+	sw.Reset()
+	sw.Section("fresh")
+	end() // belongs to the previous session
+
+	if sw.section == nil || sw.section.Name != "fresh" {
+		t.Error("Section: closing a stale section should be ignored")
+	}
+}
+
+func TestStopwatch_ReportSectionOffsetAfterPause(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+
+	c.Advance(time.Second)
+	end := sw.Section("work")
+	c.Advance(time.Second)
+	sw.Stop()
+	c.Advance(10 * time.Second)
+	sw.Start(0)
+	c.Advance(time.Second)
+	end()
+	sw.Stop()
+
+	if got := sw.report(0).Sections[0].Offset; got != time.Second {
+		t.Errorf("Report: expected a section offset of %s, got %s\n", time.Second, got)
+	}
+}
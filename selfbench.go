@@ -0,0 +1,97 @@
+package stopwatch
+
+import (
+	"runtime"
+	"time"
+)
+
+// selfBenchmarkTime is the minimum time each operation is measured for.
+const selfBenchmarkTime = 20 * time.Millisecond
+
+// selfBenchmarkBatch is the number of operations measured on the same
+// stopwatch, so laps and sections don't pile up over the rounds.
+const selfBenchmarkBatch = 1024
+
+// SelfBenchmarkResult is the measured overhead of the operations of a
+// stopwatch on the current hardware, see SelfBenchmark.
+type SelfBenchmarkResult struct {
+	GoVersion  string          `json:"go_version"`
+	GOOS       string          `json:"goos"`
+	GOARCH     string          `json:"goarch"`
+	NumCPU     int             `json:"num_cpu"`
+	Operations []OperationCost `json:"operations"`
+}
+
+// OperationCost is the measured overhead of a single operation.
+type OperationCost struct {
+	Name       string  `json:"name"`
+	NsPerOp    float64 `json:"ns_per_op"`
+	Iterations int     `json:"iterations"`
+}
+
+// SelfBenchmark measures the overhead of the public operations of a stopwatch
+// and returns the cost per operation. It does not depend on go test, it can
+// be run in production to budget the overhead of instrumentation. It takes
+// about a second to run.
+func SelfBenchmark() SelfBenchmarkResult {
+	result := SelfBenchmarkResult{
+		GoVersion: runtime.Version(),
+		GOOS:      runtime.GOOS,
+		GOARCH:    runtime.GOARCH,
+		NumCPU:    runtime.NumCPU(),
+	}
+
+	var s *Stopwatch
+	ops := []struct {
+		name  string
+		setup func()
+		op    func()
+	}{
+		{"New", nil, func() { New() }},
+		{"Start", nil, func() { Start(0) }},
+		{"ElapsedTime", func() { s = Start(0) }, func() { s.ElapsedTime() }},
+		{"Lap", func() { s = Start(0) }, func() { s.Lap() }},
+		{"LapWithHook", func() {
+			s = Start(0)
+			s.OnEvent(func(Event) {})
+		}, func() { s.Lap() }},
+		{"StopStart", func() { s = Start(0) }, func() {
+			s.Stop()
+			s.Start(0)
+		}},
+		{"Section", func() { s = Start(0) }, func() { s.Section("section")() }},
+		{"Reset", func() { s = Start(0) }, func() { s.Reset() }},
+	}
+
+	for _, o := range ops {
+		cost := OperationCost{Name: o.name}
+		for n := 1; ; n *= 2 {
+			var d time.Duration
+			for done := 0; done < n; {
+				batch := n - done
+				if o.setup != nil {
+					o.setup()
+					if batch > selfBenchmarkBatch {
+						batch = selfBenchmarkBatch
+					}
+				}
+
+				start := time.Now()
+				for i := 0; i < batch; i++ {
+					o.op()
+				}
+				d += time.Since(start)
+				done += batch
+			}
+
+			if d >= selfBenchmarkTime || n >= 1<<30 {
+				cost.Iterations = n
+				cost.NsPerOp = float64(d) / float64(n)
+				break
+			}
+		}
+		result.Operations = append(result.Operations, cost)
+	}
+
+	return result
+}
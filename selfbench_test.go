@@ -0,0 +1,35 @@
+package stopwatch
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSelfBenchmark(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the self benchmark in short mode")
+	}
+
+	r := SelfBenchmark()
+	if r.GoVersion == "" || r.NumCPU == 0 {
+		t.Errorf("SelfBenchmark: missing platform info %+v\n", r)
+	}
+
+	names := map[string]bool{}
+	for _, op := range r.Operations {
+		names[op.Name] = true
+		if op.NsPerOp <= 0 || op.Iterations == 0 {
+			t.Errorf("SelfBenchmark: unexpected cost %+v\n", op)
+		}
+	}
+
+	for _, name := range []string{"New", "Start", "ElapsedTime", "Lap", "StopStart", "Section", "Reset"} {
+		if !names[name] {
+			t.Errorf("SelfBenchmark: %s is not measured\n", name)
+		}
+	}
+
+	if _, err := json.Marshal(r); err != nil {
+		t.Errorf("SelfBenchmark: error: %s\n", err)
+	}
+}
@@ -0,0 +1,96 @@
+package stopwatch
+
+import "time"
+
+// Session is a recorded period of a stopwatch.
+type Session struct {
+	Start, End time.Time
+
+	// Elapsed is the running time of the session, pauses are excluded.
+	Elapsed time.Duration
+
+	// Laps are the laps of a session kept with WithHistory.
+	Laps []LapRecord
+}
+
+// run is a period in which the stopwatch was running. A zero to means the
+// stopwatch is still running.
+type run struct {
+	from, to time.Time
+}
+
+// Boundary returns the first boundary strictly after t. Boundaries are used
+// to split sessions, such as at every midnight.
+type Boundary func(t time.Time) time.Time
+
+// Midnight returns a Boundary at the start of every day in loc.
+func Midnight(loc *time.Location) Boundary {
+	return func(t time.Time) time.Time {
+		y, m, d := t.In(loc).Date()
+		return time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+	}
+}
+
+// Every returns a Boundary at every multiple of d since the zero time, such
+// as the top of every hour for time.Hour. See time.Time.Truncate.
+func Every(d time.Duration) Boundary {
+	return func(t time.Time) time.Time {
+		return t.Truncate(d).Add(d)
+	}
+}
+
+// WithSplit splits the session at the given boundaries in reports, so that
+// they list the running time per period, such as per calendar day. It
+// records every pause of the session, see SplitSessions.
+func WithSplit(b Boundary) Option {
+	return func(s *Stopwatch) { s.split = b }
+}
+
+// SplitSessions splits the current session at the given boundaries. Each
+// returned Session covers the running time of the stopwatch between two
+// consecutive boundaries. Periods without running time are omitted. The
+// pauses of the session are only recorded with WithSplit, without it the
+// running time is placed right before the stop, or before now if running.
+func (s *Stopwatch) SplitSessions(b Boundary) []Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.splitSessions(b)
+}
+
+func (s *Stopwatch) splitSessions(b Boundary) []Session {
+	now := s.now()
+
+	var sessions []Session
+	runs := s.runs
+	if s.split == nil && len(runs) > 0 {
+		runs = []run{{from: s.start, to: s.stop}}
+	}
+
+	var period time.Time // boundary that ends the latest session
+	for _, r := range runs {
+		to := r.to
+		if to.IsZero() {
+			to = now
+		}
+
+		for from := r.from; from.Before(to); {
+			next := b(from)
+			end := to
+			if next.After(from) && next.Before(to) {
+				end = next
+			}
+
+			if len(sessions) == 0 || !next.Equal(period) {
+				sessions = append(sessions, Session{Start: from})
+				period = next
+			}
+
+			cur := &sessions[len(sessions)-1]
+			cur.End = end
+			cur.Elapsed += end.Sub(from)
+			from = end
+		}
+	}
+
+	return sessions
+}
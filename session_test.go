@@ -0,0 +1,94 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_SplitSessions(t *testing.T) {
+	c := newClock()
+	c.Set(time.Date(2014, 2, 10, 22, 0, 0, 0, time.UTC))
+
+	sw := Start(0, WithClock(c), WithSplit(Midnight(time.UTC)))
+	c.Advance(time.Hour)
+	sw.Stop()
+	c.Advance(2 * time.Hour) // paused over midnight
+	sw.Start(0)
+	c.Advance(30 * time.Minute)
+	sw.Stop()
+	sw.Start(0)
+	c.Advance(30 * time.Minute)
+
+	sessions := sw.SplitSessions(Midnight(time.UTC))
+	if len(sessions) != 2 {
+		t.Fatalf("SplitSessions: got: %d sessions expected: %d\n", len(sessions), 2)
+	}
+
+	if sessions[0].Elapsed != time.Hour || sessions[1].Elapsed != time.Hour {
+		t.Errorf("SplitSessions: got: %s %s expected: 1h 1h\n", sessions[0].Elapsed, sessions[1].Elapsed)
+	}
+
+	if !sessions[1].Start.Equal(time.Date(2014, 2, 11, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("SplitSessions: second session starts at %s\n", sessions[1].Start)
+	}
+
+	byHour := sw.SplitSessions(Every(time.Hour))
+	if len(byHour) != 2 {
+		t.Errorf("SplitSessions: got: %d hourly sessions expected: %d\n", len(byHour), 2)
+	}
+
+	var buf bytes.Buffer
+	sw.Report(&buf, ReportMarkdown)
+	if !strings.Contains(buf.String(), "| 2 | 2014-02-11T01:00:00Z | 2014-02-11T02:00:00Z | 1h0m0s |") {
+		t.Errorf("SplitSessions: report should list the sessions:\n%s\n", buf.String())
+	}
+}
+
+func TestStopwatch_RunsWithoutSplit(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	for i := 0; i < 100; i++ {
+		c.Advance(time.Second)
+		sw.Stop()
+		c.Advance(time.Second)
+		sw.Start(0)
+	}
+
+	sw.mu.Lock()
+	runs := len(sw.runs)
+	sw.mu.Unlock()
+
+	if runs != 1 {
+		t.Errorf("Start: got: %d runs expected: 1 without WithSplit\n", runs)
+	}
+
+	sessions := sw.SplitSessions(Every(time.Hour))
+	if len(sessions) != 1 || sessions[0].Elapsed != 100*time.Second {
+		t.Errorf("SplitSessions: got: %v expected a single session of 1m40s\n", sessions)
+	}
+}
+
+func TestStopwatch_SplitSessionsRunning(t *testing.T) {
+	c := newClock()
+	c.Set(time.Date(2014, 2, 10, 23, 0, 0, 0, time.UTC))
+
+	sw := Start(0, WithClock(c))
+	c.Advance(49 * time.Hour)
+
+	sessions := sw.SplitSessions(Midnight(time.UTC))
+	if len(sessions) != 3 {
+		t.Fatalf("SplitSessions: got: %d sessions expected: %d\n", len(sessions), 3)
+	}
+
+	expected := []time.Duration{time.Hour, 24 * time.Hour, 24 * time.Hour}
+	for i, ss := range sessions {
+		if ss.Elapsed != expected[i] {
+			t.Errorf("SplitSessions: session %d got: %s expected: %s\n", i, ss.Elapsed, expected[i])
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Package statsdstopwatch sends stopwatch laps as StatsD timing metrics over
+// UDP. Tags are sent in the DogStatsD format.
+package statsdstopwatch
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/stopwatch"
+)
+
+// Option configures an Emitter.
+type Option func(*Emitter)
+
+// WithPrefix prepends prefix and a dot to all metric names. An empty prefix
+// leaves the names as they are.
+func WithPrefix(prefix string) Option {
+	return func(e *Emitter) {
+		e.prefix = ""
+		if prefix != "" {
+			e.prefix = prefix + "."
+		}
+	}
+}
+
+// tagReplacer replaces the separators of the DogStatsD format in tags.
+var tagReplacer = strings.NewReplacer(",", "_", "|", "_", "#", "_", ":", "_", "\n", "_")
+
+// tag returns the DogStatsD tag of k and v. The separators of the format in
+// k and v are replaced by underscores.
+func tag(k, v string) string {
+	return tagReplacer.Replace(k) + ":" + tagReplacer.Replace(v)
+}
+
+// WithTags attaches the given DogStatsD tags to all metrics. Characters that
+// separate the tags, such as "," or ":", are replaced by underscores.
+func WithTags(tags map[string]string) Option {
+	return func(e *Emitter) {
+		e.tags = make([]string, 0, len(tags))
+		for k, v := range tags {
+			e.tags = append(e.tags, tag(k, v))
+		}
+		sort.Strings(e.tags)
+	}
+}
+
+// Emitter sends timing metrics to a StatsD server. It is safe for concurrent
+// use. Metrics are sent fire-and-forget, errors of attached stopwatches are
+// dropped.
+type Emitter struct {
+	conn   net.Conn
+	prefix string
+	tags   []string
+}
+
+// New creates an Emitter sending to the StatsD server at addr, such as
+// "127.0.0.1:8125".
+func New(addr string, opts ...Option) (*Emitter, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Emitter{conn: conn}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e, nil
+}
+
+// Attach sends every lap of s as a timing metric with the given name. The
+// tags of the stopwatch and of the lap are sent along with the tags of the
+// emitter. The returned function stops sending the laps of s.
+func (e *Emitter) Attach(name string, s *stopwatch.Stopwatch) (detach func()) {
+	return s.OnEvent(func(ev stopwatch.Event) {
+		if ev.Kind == stopwatch.EventLap {
+			e.timing(name, ev.Duration, ev.Tags)
+		}
+	})
+}
+
+// Timing sends a single timing metric in milliseconds.
+func (e *Emitter) Timing(name string, d time.Duration) error {
+	return e.timing(name, d, nil)
+}
+
+func (e *Emitter) timing(name string, d time.Duration, extra map[string]string) error {
+	tags := e.tags
+	if len(extra) > 0 {
+		tags = make([]string, 0, len(e.tags)+len(extra))
+		tags = append(tags, e.tags...)
+		for k, v := range extra {
+			tags = append(tags, tag(k, v))
+		}
+		sort.Strings(tags)
+	}
+
+	ms := float64(d) / float64(time.Millisecond)
+
+	var b strings.Builder
+	b.WriteString(e.prefix)
+	b.WriteString(name)
+	b.WriteByte(':')
+	b.WriteString(strconv.FormatFloat(ms, 'f', -1, 64))
+	b.WriteString("|ms")
+	if len(tags) > 0 {
+		b.WriteString("|#")
+		b.WriteString(strings.Join(tags, ","))
+	}
+
+	_, err := e.conn.Write([]byte(b.String()))
+	return err
+}
+
+// Close closes the connection to the server.
+func (e *Emitter) Close() error {
+	return e.conn.Close()
+}
@@ -0,0 +1,94 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package statsdstopwatch
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/stopwatch"
+)
+
+func TestEmitter(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	defer pc.Close()
+
+	e, err := New(pc.LocalAddr().String(),
+		WithPrefix("myapp"),
+		WithTags(map[string]string{"env": "test", "region": "eu"}),
+	)
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	defer e.Close()
+
+	if err := e.Timing("direct", 1500*time.Microsecond); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	sw := stopwatch.Start(0)
+	sw.SetTag("job", "42")
+	detach := e.Attach("import", sw)
+	sw.Lap()
+
+	expected := []string{"myapp.direct:1.5|ms|#env:test,region:eu", "myapp.import:"}
+	suffixes := []string{"|ms|#env:test,region:eu", "|ms|#env:test,job:42,region:eu"}
+	buf := make([]byte, 512)
+	for i, prefix := range expected {
+		pc.SetReadDeadline(time.Now().Add(time.Second))
+		n, _, err := pc.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("error: %s\n", err)
+		}
+
+		got := string(buf[:n])
+		if !strings.HasPrefix(got, prefix) || !strings.HasSuffix(got, suffixes[i]) {
+			t.Errorf("Emitter: got: %q expected prefix: %q\n", got, prefix)
+		}
+	}
+
+	detach()
+	sw.Lap()
+	pc.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+	if n, _, err := pc.ReadFrom(buf); err == nil {
+		t.Errorf("Attach: got: %q after detach expected nothing\n", buf[:n])
+	}
+}
+
+func TestEmitter_Escaping(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	defer pc.Close()
+
+	e, err := New(pc.LocalAddr().String(),
+		WithPrefix(""),
+		WithTags(map[string]string{"a,b": "c|d#e:f"}),
+	)
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	defer e.Close()
+
+	if err := e.Timing("direct", time.Millisecond); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	buf := make([]byte, 512)
+	pc.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	if got, expected := string(buf[:n]), "direct:1|ms|#a_b:c_d_e_f"; got != expected {
+		t.Errorf("Emitter: got: %q expected: %q\n", got, expected)
+	}
+}
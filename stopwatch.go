@@ -3,56 +3,291 @@
 package stopwatch
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"runtime/trace"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
-// Stopwatch implements the stopwatch functionality. It is not threadsafe by
-// design and should be protected when there is a need for.
+// Stopwatch implements the stopwatch functionality. It is safe for concurrent
+// use, which allows collectors and handlers to read a stopwatch that is
+// driven by another goroutine.
+//
+// The zero value is a reseted stopwatch without options that is ready to
+// use, the first Start() initializes it. So a Stopwatch can be a value field
+// of another struct, without a constructor and an extra allocation. Like a
+// sync.Mutex it must not be copied after first use.
+//
+// All durations are differences between readings of the clock of the
+// stopwatch. The readings of the system clock carry the monotonic clock, see
+// the time package, so wall clock changes such as NTP steps or daylight
+// saving time don't affect the measurements.
 type Stopwatch struct {
+	// published is the state of the lock-free ElapsedTime, see publish. It
+	// comes first to be 64-bit aligned on 32-bit platforms, see sync/atomic.
+	published int64
+
+	mu          sync.Mutex
+	clock       Clock
+	speed       float64 // see WithSpeed
+	scaled      bool
+	format      func(time.Duration) string // see WithHumanDurations
+	colors      *colorThresholds           // see WithColor
+	tags        map[string]string          // see SetTag
+	budgets     map[string]time.Duration   // see SetBudget
+	deadline    time.Time                  // see SetDeadline
+	missed      bool                       // the session passed its deadline
+	postmortems bool                       // see WithPostmortem
+	watchdog    *watchdog                  // see WithWatchdog
+	onLeak      func(Leak)                 // see WithLeakDetection
+	callers     bool                       // see WithCallers
+	logger      Logger                     // see WithLogger
+	lockFree    bool                       // see WithLockFreeElapsed
+	lfClock     atomic.Value               // *lockFreeClock
+	caller      string                     // call site of the session start
+	behavior    StartBehavior
+	split       Boundary
+	minLap      time.Duration
+	authority   TimeAuthority
+
 	start, stop, lap time.Time
-	laps             []time.Duration
+	stopped          bool        // a stop can read the same instant as the start
+	laps             []LapRecord // ring buffer if maxLaps is set
+	lapHead          int         // index of the oldest lap in laps
+	lapStats         LapStats
+	lapBase          time.Duration // running time of the laps cleared
+	maxLaps          int
+	ewma             map[float64]float64 // averages by alpha, see EWMA
+	runs             []run               // running periods of the session, see WithSplit
+	history          []Session           // see WithHistory
+	maxHistory       int
+
+	sections []*Section // top level sections
+	section  *Section   // innermost open section
+
+	hooks  []hook
+	lastID uint64 // id of the latest hook or task
+	seq    uint64 // sequence number of the latest event
+	gen    uint64 // incremented by Pool.Put, stale timers do nothing
+
+	total, done   int // work items, see SetTotal
+	notifications []*etaNotification
+
+	ticks   int64         // items recorded by Tick
+	window  time.Duration // see WithRateWindow
+	tickLog []tick        // ticks within the window
+
+	cpu      bool          // see WithCPUTime
+	cpuMark  time.Duration // process CPU time at the latest start or resume
+	cpuTotal time.Duration // CPU time up to the latest stop
+
+	mem     bool        // see WithMemStats
+	memMark memSnapshot // memory statistics at the latest lap
+
+	timers map[*time.Timer]scheduled // see StartAt and StopAt
+	tasks  map[uint64]func()         // ends the background goroutines
+
+	trace     bool // see WithTrace
+	traceCtx  context.Context
+	task      *trace.Task   // task of the running period
+	lapRegion *trace.Region // region of the current lap
+}
+
+// LapRecord is a single recorded lap.
+type LapRecord struct {
+	// Seq is the sequence number of the lap event. Sequence numbers are
+	// shared by all events of a stopwatch and increase monotonically, even
+	// across resets.
+	Seq      uint64
+	Duration time.Duration
+	Split    time.Duration // elapsed time of the stopwatch at the lap
+
+	// Time is the wall clock time at which the lap was taken, which allows
+	// to correlate laps with external logs and events.
+	Time time.Time
+
+	// Allocs and GC are the allocations and the GC pauses during the lap,
+	// see WithMemStats.
+	Allocs Allocs
+	GC     GCPauses
+
+	// Tags are the tags attached with LapWithTags.
+	Tags map[string]string
+
+	// Caller is the file:line at which the lap was taken, see WithCallers.
+	Caller string
+}
+
+// Option configures a Stopwatch.
+type Option func(*Stopwatch)
+
+// StartBehavior defines what Start() does when the stopwatch is already
+// running.
+type StartBehavior int
+
+const (
+	// StartIgnore leaves a running stopwatch untouched. This is the default.
+	StartIgnore StartBehavior = iota
+
+	// StartError leaves a running stopwatch untouched and makes Start()
+	// return ErrRunning.
+	StartError
+
+	// StartRestart discards the running session and starts a new one.
+	StartRestart
+)
+
+// ErrRunning is returned by Start() for a running stopwatch that is
+// configured with StartError.
+var ErrRunning = errors.New("stopwatch: already running")
+
+// WithStartBehavior sets what Start() does on a running stopwatch.
+func WithStartBehavior(b StartBehavior) Option {
+	return func(s *Stopwatch) { s.behavior = b }
+}
+
+// WithMinLapInterval coalesces laps shorter than d. A Lap() call arriving
+// sooner than d after the latest lap is not recorded, the time is merged into
+// the pending lap instead. This protects the stored laps from accidental
+// tight loops.
+func WithMinLapInterval(d time.Duration) Option {
+	return func(s *Stopwatch) { s.minLap = d }
 }
 
 // New creates a new Stopwatch. To start the stopwatch Start() should be invoked.
-func New() *Stopwatch {
-	return &Stopwatch{
-		laps: make([]time.Duration, 0),
+func New(opts ...Option) *Stopwatch {
+	s := &Stopwatch{
+		laps: make([]LapRecord, 0),
+	}
+	s.init(opts)
+	return s
+}
+
+// init applies the given options to a new stopwatch.
+func (s *Stopwatch) init(opts []Option) {
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if c, ok := s.clock.(MonotonicClock); ok {
+		s.clock = newMonotonicClock(c)
+	}
+	if s.scaled {
+		c := clockOrSystem(s.clock)
+		s.clock = &scaledClock{clock: c, factor: s.speed, origin: c.Now()}
+	}
+	s.initLockFree()
+
+	if s.onLeak != nil {
+		watchLeak(s)
 	}
 }
 
 // Start creates a new stopwatch with starting time offset by a user defined
 // value. Negative offsets result in a countdown prior to the start of the
 // stopwatch. A zero offset starts the stopwatch immediately.
-func Start(offset time.Duration) *Stopwatch {
-	t := time.Now().Add(offset)
-	s := &Stopwatch{
-		start: t,
-		lap:   t,
-		laps:  make([]time.Duration, 0),
+func Start(offset time.Duration, opts ...Option) *Stopwatch {
+	s := New(opts...)
+	s.mu.Lock()
+	s.begin(offset)
+	s.mu.Unlock()
+	return s
+}
+
+// Resume creates a new stopwatch that picks up a measurement where it left
+// off, such as one persisted before a restart of the process. The stopwatch
+// has the given elapsed time and is running or stopped according to running.
+// A stopped stopwatch without elapsed time is reseted.
+func Resume(elapsed time.Duration, running bool, opts ...Option) *Stopwatch {
+	s := New(opts...)
+	if !running && elapsed <= 0 {
+		return s
+	}
+
+	s.mu.Lock()
+	s.begin(-elapsed)
+	s.mu.Unlock()
+	if !running {
+		s.Stop()
+		s.SetElapsed(elapsed)
 	}
 	return s
 }
 
+// begin starts a new session with the given offset.
+func (s *Stopwatch) begin(offset time.Duration) {
+	if !Enabled {
+		return
+	}
+
+	t := s.now().Add(offset)
+	s.start, s.stop, s.lap = t, time.Time{}, t
+	s.stopped = false
+	s.caller = s.callerOf()
+	s.publish()
+	s.resetLaps()
+	s.runs = append(s.runs[:0], run{from: t})
+	s.sections, s.section = nil, nil
+	s.resetProgress()
+	s.resetRate()
+	s.resetCPU()
+	s.markMem()
+	s.startTrace()
+	if s.watchdog != nil {
+		s.watchdog.tripped = false
+	}
+	s.armWatchdog()
+	s.missed = false
+}
+
 // IsStopped shows whether the stopwatch is stopped or not.
-func (s *Stopwatch) IsStopped() bool { return s.stop.After(s.start) }
+func (s *Stopwatch) IsStopped() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.isStopped()
+}
+
+func (s *Stopwatch) isStopped() bool { return s.stopped }
 
 // IsReseted shows whether the stopwatch is reseted or not.
-func (s *Stopwatch) IsReseted() bool { return s.start.IsZero() }
+func (s *Stopwatch) IsReseted() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.isReseted()
+}
+
+func (s *Stopwatch) isReseted() bool { return s.start.IsZero() }
 
-// ElapsedTime returns the duration between the start and current time.
+func (s *Stopwatch) isRunning() bool { return !s.isStopped() && !s.isReseted() }
+
+// ElapsedTime returns the duration between the start and current time. See
+// WithLockFreeElapsed to read it without locking.
 func (s *Stopwatch) ElapsedTime() time.Duration {
-	if s.IsStopped() {
+	if d, ok := s.loadElapsed(); ok {
+		return d
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.elapsed()
+}
+
+func (s *Stopwatch) elapsed() time.Duration {
+	if s.isStopped() {
 		return s.stop.Sub(s.start)
 	}
 
-	if s.IsReseted() {
+	if s.isReseted() {
 		return time.Duration(0)
 	}
 
-	return time.Since(s.start)
+	return s.since(s.start)
 }
 
 // Print calls fmt.Printf() with the given string and the elapsed time attached.
@@ -60,66 +295,242 @@ func (s *Stopwatch) ElapsedTime() time.Duration {
 // Example : defer Start().Print("myFunction")
 // Output  :  myFunction - elapsed: 2.000629842s
 func (s *Stopwatch) Print(msg string) {
-	fmt.Printf("%s - elapsed: %s\n", msg, s.ElapsedTime())
+	fmt.Printf("%s - elapsed: %s\n", msg, s.colorFormat(os.Stdout)(s.ElapsedTime()))
 }
 
-// Log calls log.Printf() with the given string and the elapsed time attached.
-// Useful to use with a defer statement.
+// Log logs the given string and the elapsed time with the logger of the
+// stopwatch, see WithLogger and SetLogger. Without a logger it calls
+// log.Printf. Useful to use with a defer statement.
 // Example : defer Start().Log("myFunction")
 // Output: 2014/02/10 00:44:56 myFunction - elapsed: 2.000169591s
 func (s *Stopwatch) Log(msg string) {
-	log.Printf("%s - elapsed: %s\n", msg, s.ElapsedTime())
+	l := s.loggerOrDefault()
+	if l == nil {
+		log.Printf("%s - elapsed: %s\n", msg, s.colorFormat(log.Writer())(s.ElapsedTime()))
+		return
+	}
+	l.LogElapsed(msg, s.ElapsedTime(), s.Tags())
 }
 
 // Stop stops the timer. To resume the timer Start() needs to be called again.
+// Stopping a stopped or reseted stopwatch has no effect.
 func (s *Stopwatch) Stop() {
-	s.stop = time.Now()
+	if !Enabled {
+		return
+	}
+
+	s.mu.Lock()
+	if s.isStopped() || s.isReseted() {
+		s.mu.Unlock()
+		return
+	}
+
+	var buf [2]Event // avoids an allocation, see Pool
+	events := s.checkDeadline(buf[:0])
+	s.stop, s.stopped = s.now(), true
+	s.publish()
+	s.pauseCPU()
+	if n := len(s.runs); n > 0 {
+		s.runs[n-1].to = s.stop
+	}
+	s.endTrace()
+	s.disarmWatchdog()
+	s.unlock(append(events, s.event(EventStop))...)
 }
 
 // Start resumes or starts the timer. If a Stop() was invoked it resumes the
 // timer. If a Reset() was invoked it starts a new session with the given
-// offset.
-func (s *Stopwatch) Start(offset time.Duration) {
-	if s.IsReseted() {
-		*s = *Start(offset)
-	} else { //stopped
-		s.start = s.start.Add(time.Since(s.stop))
+// offset. Calling Start() on a running stopwatch is handled according to its
+// StartBehavior, by default it has no effect.
+func (s *Stopwatch) Start(offset time.Duration) error {
+	if !Enabled {
+		return nil
+	}
+
+	s.mu.Lock()
+
+	var buf [2]Event // avoids an allocation, see Pool
+	events := buf[:0]
+	switch {
+	case s.isReseted():
+		s.begin(offset)
+	case s.isStopped():
+		// start is shifted by the pause, both readings carry the
+		// monotonic clock, so wall clock changes don't skew the pause
+		now := s.now()
+		s.start = s.start.Add(now.Sub(s.stop))
+		s.stop, s.stopped = time.Time{}, false
+		s.publish()
+		if s.split != nil {
+			s.runs = append(s.runs, run{from: now})
+		}
+		s.resumeCPU()
+		s.startTrace()
+		s.armWatchdog()
+	case s.behavior == StartRestart:
+		events = append(events, s.event(EventReset))
+		s.keepSession()
+		s.begin(offset)
+	case s.behavior == StartError:
+		s.mu.Unlock()
+		return ErrRunning
+	default: // StartIgnore
+		s.mu.Unlock()
+		return nil
 	}
+
+	s.unlock(append(events, s.event(EventStart))...)
+	return nil
+}
+
+// Restart resets the timer and starts a new session in one step, no other
+// goroutine observes the reseted stopwatch in between. It returns the elapsed
+// time of the previous session.
+func (s *Stopwatch) Restart() time.Duration {
+	if !Enabled {
+		return time.Duration(0)
+	}
+
+	s.mu.Lock()
+	elapsed := s.elapsed()
+
+	var events []Event
+	if !s.isReseted() {
+		events = append(events, s.event(EventReset))
+	}
+	s.keepSession()
+	s.begin(0)
+
+	s.unlock(append(events, s.event(EventStart))...)
+	return elapsed
 }
 
 // Reset resets the timer. It needs to be started again with the Start()
 // method.
 func (s *Stopwatch) Reset() {
+	if !Enabled {
+		return
+	}
+
+	s.mu.Lock()
+	e := s.event(EventReset)
+	s.keepSession()
 	s.start, s.stop, s.lap = time.Time{}, time.Time{}, time.Time{}
-	s.laps = nil
+	s.stopped = false
+	s.caller = ""
+	s.publish()
+	s.resetLaps()
+	s.runs = s.runs[:0]
+	s.sections, s.section = nil, nil
+	s.resetProgress()
+	s.resetRate()
+	s.endTrace()
+	s.disarmWatchdog()
+	s.unlock(e)
 }
 
 // Lap takes and stores the current lap time and returns the elapsed time
-// since the latest lap.
+// since the latest lap. It returns zero if the lap was coalesced, see
+// WithMinLapInterval.
 func (s *Stopwatch) Lap() time.Duration {
-	// There is no lap if the timer is resetted or stoped
-	if s.IsStopped() || s.IsReseted() {
+	return s.mark(nil).Duration
+}
+
+// Split takes and stores the current lap time like Lap, but returns the
+// elapsed time of the stopwatch at the lap instead. It returns zero if no lap
+// was taken.
+func (s *Stopwatch) Split() time.Duration {
+	return s.mark(nil).Split
+}
+
+// SinceLap returns the time since the latest lap, or since the start if no
+// lap was taken, without taking a new lap. Useful to show the current lap in
+// progress displays. A stopped stopwatch returns the time up to the stop.
+func (s *Stopwatch) SinceLap() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case s.isReseted():
 		return time.Duration(0)
+	case s.isStopped():
+		return s.stop.Sub(s.lap)
+	}
+	return s.since(s.lap)
+}
+
+// mark takes and stores the current lap time with the given tags. It returns
+// a zero record if the lap was not taken.
+func (s *Stopwatch) mark(tags map[string]string) LapRecord {
+	if !Enabled {
+		return LapRecord{}
+	}
+
+	caller := s.callerOf()
+	s.mu.Lock()
+
+	// There is no lap if the timer is resetted or stoped
+	if s.isStopped() || s.isReseted() {
+		s.mu.Unlock()
+		return LapRecord{}
+	}
+
+	now := s.now()
+	lap := now.Sub(s.lap)
+	if lap < s.minLap {
+		s.mu.Unlock()
+		return LapRecord{}
 	}
+	s.lap = now
 
-	lap := time.Since(s.lap)
-	s.lap = time.Now()
-	s.laps = append(s.laps, lap)
+	e := s.event(EventLap)
+	e.Duration = lap
+	r := LapRecord{Seq: e.Seq, Duration: lap, Split: e.Elapsed, Time: now, Tags: mergeTags(nil, tags), Caller: caller}
+	if len(tags) > 0 {
+		e.Tags = mergeTags(s.tags, tags)
+	}
+	r.Allocs, r.GC = s.markMem()
+	s.addLap(r)
+	s.lapTrace()
 
-	return lap
+	var buf [2]Event
+	s.unlock(s.checkDeadline(append(buf[:0], e))...)
+
+	return r
 }
 
-// Laps returns a slice of all completed laps.
+// Laps returns a slice of all completed laps, see WithMaxLaps.
 func (s *Stopwatch) Laps() []time.Duration {
-	laps := make([]time.Duration, len(s.laps))
-	copy(laps, s.laps)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	laps := make([]time.Duration, 0, len(s.laps))
+	for _, lap := range s.lapRecords() {
+		laps = append(laps, lap.Duration)
+	}
 	return laps
 }
 
+// LapRecords returns a slice of all completed laps with their sequence
+// numbers, see WithMaxLaps.
+func (s *Stopwatch) LapRecords() []LapRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lapRecords()
+}
+
 // String representation of a single Stopwatch instance.
 func (s *Stopwatch) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.caller != "" {
+		return fmt.Sprintf("[start: %s current: %s elapsed: %s caller: %s]",
+			s.start.Format(time.Stamp), s.now().Format(time.Stamp), s.formatDuration(s.elapsed()), s.caller)
+	}
+
 	return fmt.Sprintf("[start: %s current: %s elapsed: %s]",
-		s.start.Format(time.Stamp), time.Now().Format(time.Stamp), s.ElapsedTime())
+		s.start.Format(time.Stamp), s.now().Format(time.Stamp), s.formatDuration(s.elapsed()))
 }
 
 // MarshalJSON implements the json.Marshaler interface. The elapsed time is
@@ -138,8 +549,16 @@ func (s *Stopwatch) UnmarshalJSON(data []byte) (err error) {
 	if err != nil {
 		return err
 	}
+	if !Enabled {
+		return nil
+	}
 
 	// set the start time based on the elapsed time
-	s.start = time.Now().Add(-d)
+	s.mu.Lock()
+	s.start = s.now().Add(-d)
+	s.lap, s.stopped = s.start, false
+	s.runs = []run{{from: s.start}}
+	s.publish()
+	s.mu.Unlock()
 	return nil
 }
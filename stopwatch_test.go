@@ -1,7 +1,11 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
 package stopwatch
 
 import (
 	"encoding/json"
+	"reflect"
 	"strconv"
 	"testing"
 	"time"
@@ -215,3 +219,121 @@ func RoundFloat(x float64, prec int) float64 {
 	f, _ := strconv.ParseFloat(frep, 64)
 	return f
 }
+
+func TestStopwatch_StartRunning(t *testing.T) {
+	c := newClock()
+
+	sw := Start(0, WithClock(c))
+	c.Advance(time.Second)
+	if err := sw.Start(0); err != nil {
+		t.Errorf("error: %s\n", err)
+	}
+
+	if e := sw.ElapsedTime(); e != time.Second {
+		t.Errorf("StartIgnore: got: %s expected: %s\n", e, time.Second)
+	}
+
+	sw = Start(0, WithClock(c), WithStartBehavior(StartError))
+	c.Advance(time.Second)
+	if err := sw.Start(0); err != ErrRunning {
+		t.Errorf("StartError: got: %v expected: %v\n", err, ErrRunning)
+	}
+
+	sw = Start(0, WithClock(c), WithStartBehavior(StartRestart))
+	c.Advance(time.Second)
+	sw.Lap()
+	if err := sw.Start(0); err != nil {
+		t.Errorf("error: %s\n", err)
+	}
+
+	if e := sw.ElapsedTime(); e != 0 || len(sw.Laps()) != 0 {
+		t.Errorf("StartRestart: got: %s and %d laps expected a new session\n", e, len(sw.Laps()))
+	}
+}
+
+func TestStopwatch_Restart(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+
+	var kinds []EventKind
+	sw.OnEvent(func(e Event) { kinds = append(kinds, e.Kind) })
+
+	c.Advance(time.Second)
+	sw.Lap()
+	sw.Stop()
+
+	if prev := sw.Restart(); prev != time.Second {
+		t.Errorf("Restart: got: %s expected: 1s\n", prev)
+	}
+	if sw.IsStopped() || sw.ElapsedTime() != 0 || len(sw.Laps()) != 0 {
+		t.Errorf("Restart: got: %s and %d laps expected a new running session\n", sw.ElapsedTime(), len(sw.Laps()))
+	}
+
+	expected := []EventKind{EventLap, EventStop, EventReset, EventStart}
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Errorf("Restart: got events: %v expected: %v\n", kinds, expected)
+	}
+}
+
+func TestStopwatch_Resume(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+
+	c.Advance(10 * time.Second)
+	sw.Stop()
+	c.Advance(time.Second) // shorter pause than the elapsed time
+	sw.Start(0)
+	c.Advance(time.Second)
+
+	if sw.IsStopped() {
+		t.Error("Resume: the stopwatch should be running")
+	}
+
+	if e := sw.ElapsedTime(); e != 11*time.Second {
+		t.Errorf("Resume: got: %s expected: %s\n", e, 11*time.Second)
+	}
+}
+
+func TestStopwatch_MinLapInterval(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c), WithMinLapInterval(time.Second))
+
+	for i := 0; i < 15; i++ {
+		c.Advance(100 * time.Millisecond)
+		sw.Lap()
+	}
+
+	c.Advance(time.Second)
+	sw.Lap()
+
+	laps := sw.Laps()
+	if len(laps) != 2 || laps[0] != time.Second || laps[1] != 1500*time.Millisecond {
+		t.Errorf("MinLapInterval: got: %v expected: [1s 1.5s]\n", laps)
+	}
+}
+
+func TestStopwatch_ZeroValue(t *testing.T) {
+	var job struct {
+		Name  string
+		Timer Stopwatch
+	}
+
+	if !job.Timer.IsReseted() || job.Timer.ElapsedTime() != 0 || job.Timer.Lap() != 0 {
+		t.Error("ZeroValue: the zero value should be a reseted stopwatch")
+	}
+
+	job.Timer.Start(0)
+	job.Timer.Lap()
+	job.Timer.Section("load")()
+	job.Timer.SetTag("job", "a")
+	job.Timer.Stop()
+
+	if !job.Timer.IsStopped() || len(job.Timer.Laps()) != 1 || len(job.Timer.Sections()) != 1 {
+		t.Errorf("ZeroValue: got: %s laps: %v expected a stopped stopwatch with a lap\n", &job.Timer, job.Timer.Laps())
+	}
+
+	job.Timer.Reset()
+	if !job.Timer.IsReseted() || len(job.Timer.Laps()) != 0 {
+		t.Error("ZeroValue: Reset should reset the stopwatch")
+	}
+}
@@ -0,0 +1,52 @@
+// Package stopwatchtest provides stopwatches for tests, which log their
+// elapsed time and assert duration budgets. They are meant for coarse
+// performance regression gates in integration tests.
+package stopwatchtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fatih/stopwatch"
+)
+
+// Stopwatch is a stopwatch bound to a test.
+type Stopwatch struct {
+	*stopwatch.Stopwatch
+	tb testing.TB
+}
+
+// Start starts a new stopwatch for tb. The elapsed time is logged with
+// tb.Logf once the test and all its subtests are completed.
+func Start(tb testing.TB, opts ...stopwatch.Option) *Stopwatch {
+	tb.Helper()
+
+	s := &Stopwatch{Stopwatch: stopwatch.Start(0, opts...), tb: tb}
+	tb.Cleanup(func() {
+		tb.Logf("%s - elapsed: %s", tb.Name(), s.ElapsedTime())
+	})
+
+	return s
+}
+
+// RequireUnder fails the test and stops its execution if the elapsed time
+// exceeds max.
+func (s *Stopwatch) RequireUnder(max time.Duration) {
+	s.tb.Helper()
+
+	if elapsed := s.ElapsedTime(); elapsed > max {
+		s.tb.Fatalf("elapsed: %s exceeds: %s", elapsed, max)
+	}
+}
+
+// AssertUnder marks the test as failed if the elapsed time exceeds max, the
+// test continues. It reports whether the elapsed time is within max.
+func (s *Stopwatch) AssertUnder(max time.Duration) bool {
+	s.tb.Helper()
+
+	if elapsed := s.ElapsedTime(); elapsed > max {
+		s.tb.Errorf("elapsed: %s exceeds: %s", elapsed, max)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatchtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/stopwatch"
+	"github.com/fatih/stopwatch/clocktest"
+)
+
+// fakeTB records the calls of the stopwatch.
+type fakeTB struct {
+	testing.TB
+	logs, errors, fatals []string
+	cleanups             []func()
+}
+
+func (tb *fakeTB) Helper()          {}
+func (tb *fakeTB) Name() string     { return "TestFake" }
+func (tb *fakeTB) Cleanup(f func()) { tb.cleanups = append(tb.cleanups, f) }
+func (tb *fakeTB) Logf(format string, args ...interface{}) {
+	tb.logs = append(tb.logs, fmt.Sprintf(format, args...))
+}
+func (tb *fakeTB) Errorf(format string, args ...interface{}) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+func (tb *fakeTB) Fatalf(format string, args ...interface{}) {
+	tb.fatals = append(tb.fatals, fmt.Sprintf(format, args...))
+}
+
+func TestStart(t *testing.T) {
+	c := clocktest.NewClock(time.Date(2014, 2, 10, 0, 0, 0, 0, time.UTC))
+	tb := &fakeTB{}
+
+	sw := Start(tb, stopwatch.WithClock(c))
+	c.Advance(time.Second)
+
+	sw.RequireUnder(2 * time.Second)
+	if !sw.AssertUnder(2 * time.Second) {
+		t.Error("AssertUnder: should report true within the budget")
+	}
+
+	if len(tb.errors) != 0 || len(tb.fatals) != 0 {
+		t.Errorf("Start: unexpected failures %v %v\n", tb.errors, tb.fatals)
+	}
+
+	c.Advance(2 * time.Second)
+	if sw.AssertUnder(2 * time.Second) {
+		t.Error("AssertUnder: should report false over the budget")
+	}
+	sw.RequireUnder(2 * time.Second)
+
+	if len(tb.errors) != 1 || tb.errors[0] != "elapsed: 3s exceeds: 2s" {
+		t.Errorf("AssertUnder: got: %v expected: [elapsed: 3s exceeds: 2s]\n", tb.errors)
+	}
+	if len(tb.fatals) != 1 {
+		t.Errorf("RequireUnder: got: %v expected a single fatal\n", tb.fatals)
+	}
+
+	for _, f := range tb.cleanups {
+		f()
+	}
+	if len(tb.logs) != 1 || !strings.Contains(tb.logs[0], "TestFake - elapsed: 3s") {
+		t.Errorf("Start: got: %v expected the elapsed time to be logged\n", tb.logs)
+	}
+}
+
+func TestStart_Real(t *testing.T) {
+	sw := Start(t)
+	sw.Lap()
+	sw.RequireUnder(time.Minute)
+}
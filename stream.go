@@ -0,0 +1,86 @@
+package stopwatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// StreamHandler returns an http.Handler that streams the live state of the
+// registered stopwatches as Server-Sent Events. Every interval an event is
+// sent that holds the same JSON as served by Handler, the name query
+// parameter selects a single stopwatch and the interval query parameter, such
+// as "500ms", overrides the interval. Requests are rejected with 400 Bad
+// Request unless the interval is positive. The stream ends when the client
+// disconnects.
+//
+// Example (JavaScript):
+//
+//	new EventSource("/debug/stopwatch/stream?name=build").onmessage = e => {
+//		const state = JSON.parse(e.data)
+//	}
+func StreamHandler(interval time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "stopwatch: streaming is not supported", http.StatusInternalServerError)
+			return
+		}
+
+		interval := interval
+		if v := r.URL.Query().Get("interval"); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				http.Error(w, "stopwatch: invalid interval "+v, http.StatusBadRequest)
+				return
+			}
+			interval = d
+		}
+		if interval <= 0 {
+			http.Error(w, "stopwatch: the interval must be positive", http.StatusBadRequest)
+			return
+		}
+
+		name := r.URL.Query().Get("name")
+		if name != "" && Lookup(name) == nil {
+			http.Error(w, "stopwatch: unknown stopwatch "+name, http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			var v interface{}
+			if name != "" {
+				s := Lookup(name)
+				if s == nil { // unregistered in the meantime
+					return
+				}
+				v = s.debugState()
+			} else {
+				v = debugStates()
+			}
+
+			data, err := json.Marshal(v)
+			if err != nil {
+				return
+			}
+
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return
+			}
+			flusher.Flush()
+
+			select {
+			case <-r.Context().Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+}
@@ -0,0 +1,103 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStreamHandler(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	Register("stream-test", sw)
+	defer Unregister("stream-test")
+
+	srv := httptest.NewServer(StreamHandler(time.Millisecond))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "?name=stream-test")
+	if err != nil {
+		t.Fatalf("StreamHandler: error: %s\n", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("StreamHandler: got: %s expected: %s\n", ct, "text/event-stream")
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	next := func() debugState {
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, "data: ") {
+				continue
+			}
+
+			var state debugState
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &state); err != nil {
+				t.Fatalf("StreamHandler: error: %s\n", err)
+			}
+			return state
+		}
+		t.Fatalf("StreamHandler: the stream ended: %v\n", scanner.Err())
+		return debugState{}
+	}
+
+	if state := next(); state.State != "running" {
+		t.Errorf("StreamHandler: got: %s expected: %s\n", state.State, "running")
+	}
+
+	c.Advance(time.Second)
+	sw.Lap()
+
+	// events can be in flight, wait for the lap to show up
+	deadline := time.Now().Add(time.Second)
+	for {
+		state := next()
+		if len(state.Laps) == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("StreamHandler: the lap was not streamed\n")
+		}
+	}
+
+	resp2, err := http.Get(srv.URL + "?name=unknown")
+	if err != nil {
+		t.Fatalf("StreamHandler: error: %s\n", err)
+	}
+	resp2.Body.Close()
+	if resp2.StatusCode != http.StatusNotFound {
+		t.Errorf("StreamHandler: got: %d expected: %d\n", resp2.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestStreamHandler_Interval(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		query    string
+	}{
+		{0, ""},
+		{-time.Second, ""},
+		{time.Second, "?interval=0s"},
+		{time.Second, "?interval=-1s"},
+		{time.Second, "?interval=often"},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/"+test.query, nil)
+		rec := httptest.NewRecorder()
+		StreamHandler(test.interval).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("StreamHandler(%s)%s: got: %d expected: %d\n", test.interval, test.query, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package stopwatch
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// SetTag attaches the key/value tag to the stopwatch, such as a job
+// identifier. Tags are carried by the events, reports, exports and
+// checkpoints of the stopwatch. Setting an empty value removes the tag. Tags
+// are kept across resets.
+func (s *Stopwatch) SetTag(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if value == "" {
+		delete(s.tags, key)
+		return
+	}
+
+	if s.tags == nil {
+		s.tags = make(map[string]string)
+	}
+	s.tags[key] = value
+}
+
+// Tag returns the value of the tag with the given key and whether it is set.
+func (s *Stopwatch) Tag(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.tags[key]
+	return v, ok
+}
+
+// Tags returns a copy of the tags of the stopwatch.
+func (s *Stopwatch) Tags() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return mergeTags(s.tags, nil)
+}
+
+// LapWithTags takes a lap like Lap and attaches the given tags to it, see
+// LapRecord.Tags.
+// Example : s.LapWithTags(map[string]string{"stage": "parse"})
+func (s *Stopwatch) LapWithTags(tags map[string]string) time.Duration {
+	return s.mark(tags).Duration
+}
+
+// mergeTags returns a new map with the tags of a and b, b wins on conflicts.
+// It returns nil if both are empty.
+func mergeTags(a, b map[string]string) map[string]string {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+
+	m := make(map[string]string, len(a)+len(b))
+	for k, v := range a {
+		m[k] = v
+	}
+	for k, v := range b {
+		m[k] = v
+	}
+	return m
+}
+
+// formatTags returns the tags as "k1=v1, k2=v2", sorted by key.
+func formatTags(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for i, k := range keys {
+		keys[i] = k + "=" + tags[k]
+	}
+	return strings.Join(keys, ", ")
+}
@@ -0,0 +1,111 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_Tags(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	sw.SetTag("job", "42")
+	sw.SetTag("host", "a")
+	sw.SetTag("host", "")
+
+	if v, ok := sw.Tag("job"); !ok || v != "42" {
+		t.Errorf("Tag: got: %q %t expected: 42 true\n", v, ok)
+	}
+	if _, ok := sw.Tag("host"); ok {
+		t.Error("SetTag: an empty value should remove the tag")
+	}
+
+	var events []Event
+	sw.OnEvent(func(e Event) { events = append(events, e) })
+
+	c.Advance(time.Second)
+	sw.LapWithTags(map[string]string{"stage": "parse"})
+	c.Advance(time.Second)
+	sw.Lap()
+
+	if len(events) != 2 {
+		t.Fatalf("LapWithTags: got: %d events expected: 2\n", len(events))
+	}
+	if got := formatTags(events[0].Tags); got != "job=42, stage=parse" {
+		t.Errorf("LapWithTags: got event tags: %s expected: job=42, stage=parse\n", got)
+	}
+	if got := formatTags(events[1].Tags); got != "job=42" {
+		t.Errorf("Lap: got event tags: %s expected: job=42\n", got)
+	}
+
+	laps := sw.LapRecords()
+	if got := formatTags(laps[0].Tags); got != "stage=parse" {
+		t.Errorf("LapWithTags: got lap tags: %s expected: stage=parse\n", got)
+	}
+	if laps[1].Tags != nil {
+		t.Errorf("Lap: got lap tags: %v expected: none\n", laps[1].Tags)
+	}
+
+	sw.Reset()
+	if got := formatTags(sw.Tags()); got != "job=42" {
+		t.Errorf("Reset: got tags: %s expected: job=42\n", got)
+	}
+}
+
+func TestStopwatch_TagsExport(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+	sw.SetTag("job", "42")
+	start := c.Now().UnixNano()
+
+	c.Advance(time.Second)
+	sw.LapWithTags(map[string]string{"stage": "parse"})
+	sw.Stop()
+
+	var buf bytes.Buffer
+	if err := sw.ExportLineProtocol(&buf, "m", map[string]string{"host": "a"}); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	expected := []string{
+		`m,host=a,job=42,stage=parse,kind=lap lap=0i,seq=1i,duration_ns=1000000000i ` + itoa(start+int64(time.Second)),
+		`m,host=a,job=42,kind=session elapsed_ns=1000000000i,laps=1i ` + itoa(start+int64(time.Second)),
+	}
+	if got := strings.TrimSpace(buf.String()); got != strings.Join(expected, "\n") {
+		t.Errorf("ExportLineProtocol: got:\n%s\nexpected:\n%s\n", got, strings.Join(expected, "\n"))
+	}
+
+	buf.Reset()
+	if err := sw.Report(&buf, ReportJSON); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	var report struct {
+		Tags map[string]string
+		Laps []struct{ Tags map[string]string }
+	}
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	if report.Tags["job"] != "42" || len(report.Laps) != 1 || report.Laps[0].Tags["stage"] != "parse" {
+		t.Errorf("ReportJSON: got: %s expected job and stage tags\n", buf.String())
+	}
+
+	buf.Reset()
+	if err := sw.WriteCheckpoint(&buf); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	restored, err := ReadCheckpoint(&buf)
+	if err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+	if v, _ := restored.Tag("job"); v != "42" {
+		t.Errorf("ReadCheckpoint: got tag: %q expected: 42\n", v)
+	}
+}
@@ -0,0 +1,31 @@
+package stopwatch
+
+import "time"
+
+// Time calls fn and returns how long it took.
+// Example : d := stopwatch.Time(func() { sync() })
+func Time(fn func()) time.Duration {
+	s := Start(0)
+	fn()
+	s.Stop()
+	return s.ElapsedTime()
+}
+
+// TimeVal calls fn and returns its result and how long it took.
+// Example : users, d := stopwatch.TimeVal(loadUsers)
+func TimeVal[T any](fn func() T) (T, time.Duration) {
+	s := Start(0)
+	v := fn()
+	s.Stop()
+	return v, s.ElapsedTime()
+}
+
+// TimeValErr calls fn and returns its result, how long it took and its
+// error.
+// Example : users, d, err := stopwatch.TimeValErr(loadUsers)
+func TimeValErr[T any](fn func() (T, error)) (T, time.Duration, error) {
+	s := Start(0)
+	v, err := fn()
+	s.Stop()
+	return v, s.ElapsedTime(), err
+}
@@ -0,0 +1,40 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTime(t *testing.T) {
+	d := Time(func() { time.Sleep(10 * time.Millisecond) })
+	if d < 10*time.Millisecond {
+		t.Errorf("Time: got: %s expected at least: %s\n", d, 10*time.Millisecond)
+	}
+}
+
+func TestTimeVal(t *testing.T) {
+	v, d := TimeVal(func() int {
+		time.Sleep(10 * time.Millisecond)
+		return 42
+	})
+
+	if v != 42 || d < 10*time.Millisecond {
+		t.Errorf("TimeVal: got: %d %s expected: 42 and at least %s\n", v, d, 10*time.Millisecond)
+	}
+}
+
+func TestTimeValErr(t *testing.T) {
+	errFailed := errors.New("failed")
+	v, d, err := TimeValErr(func() (string, error) {
+		time.Sleep(10 * time.Millisecond)
+		return "partial", errFailed
+	})
+
+	if v != "partial" || err != errFailed || d < 10*time.Millisecond {
+		t.Errorf("TimeValErr: got: %q %s %v\n", v, d, err)
+	}
+}
@@ -0,0 +1,117 @@
+package stopwatch
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// thread ids used to group events into tracks in the trace viewer.
+const (
+	traceTidLaps     = 1
+	traceTidSections = 2
+)
+
+// traceEvent is a single event of the Chrome trace-event format.
+type traceEvent struct {
+	Name string                 `json:"name"`
+	Cat  string                 `json:"cat,omitempty"`
+	Ph   string                 `json:"ph"`
+	Ts   float64                `json:"ts"`
+	Dur  float64                `json:"dur,omitempty"`
+	Pid  int                    `json:"pid"`
+	Tid  int                    `json:"tid"`
+	Args map[string]interface{} `json:"args,omitempty"`
+}
+
+// ExportTraceJSON writes the session, its laps and sections in the Chrome
+// trace-event JSON format. The result can be loaded into chrome://tracing or
+// Perfetto. Timestamps are relative to the start of the session.
+func (s *Stopwatch) ExportTraceJSON(w io.Writer) error {
+	events := []traceEvent{
+		traceThreadName(traceTidLaps, "laps"),
+		traceThreadName(traceTidSections, "sections"),
+	}
+
+	s.mu.Lock()
+	if !s.isReseted() {
+		events = append(events, traceEvent{
+			Name: "session",
+			Cat:  "session",
+			Ph:   "X",
+			Dur:  traceMicros(s.elapsed()),
+			Pid:  1,
+			Tid:  traceTidLaps,
+			Args: traceCaller(nil, s.caller),
+		})
+
+		offset, first := s.lapOffset(), s.lapStats.Count-len(s.laps)
+		for i, lap := range s.lapRecords() {
+			events = append(events, traceEvent{
+				Name: "lap",
+				Cat:  "lap",
+				Ph:   "X",
+				Ts:   traceMicros(offset),
+				Dur:  traceMicros(lap.Duration),
+				Pid:  1,
+				Tid:  traceTidLaps,
+				Args: traceCaller(map[string]interface{}{"index": first + i, "seq": lap.Seq}, lap.Caller),
+			})
+			offset += lap.Duration
+		}
+
+		events = s.traceSections(events, s.sections)
+	}
+	s.mu.Unlock()
+
+	return json.NewEncoder(w).Encode(struct {
+		TraceEvents     []traceEvent `json:"traceEvents"`
+		DisplayTimeUnit string       `json:"displayTimeUnit"`
+	}{events, "ms"})
+}
+
+// traceSections appends the given sections and their children as complete
+// events. The lock must be held.
+func (s *Stopwatch) traceSections(events []traceEvent, sections []*Section) []traceEvent {
+	for _, c := range sections {
+		events = append(events, traceEvent{
+			Name: c.Name,
+			Cat:  "section",
+			Ph:   "X",
+			Ts:   traceMicros(c.offset),
+			Dur:  traceMicros(c.Elapsed()),
+			Pid:  1,
+			Tid:  traceTidSections,
+			Args: traceCaller(nil, c.Caller),
+		})
+		events = s.traceSections(events, c.Children)
+	}
+
+	return events
+}
+
+func traceThreadName(tid int, name string) traceEvent {
+	return traceEvent{
+		Name: "thread_name",
+		Ph:   "M",
+		Pid:  1,
+		Tid:  tid,
+		Args: map[string]interface{}{"name": name},
+	}
+}
+
+// traceCaller adds the caller to args if it is set, see WithCallers.
+func traceCaller(args map[string]interface{}, caller string) map[string]interface{} {
+	if caller == "" {
+		return args
+	}
+	if args == nil {
+		args = make(map[string]interface{})
+	}
+	args["caller"] = caller
+	return args
+}
+
+func traceMicros(d time.Duration) float64 {
+	return float64(d) / float64(time.Microsecond)
+}
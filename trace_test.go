@@ -0,0 +1,84 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestStopwatch_ExportTraceJSON(t *testing.T) {
+	sw := Start(0)
+	end := sw.Section("work")
+	time.Sleep(time.Millisecond * 10)
+	sw.Lap()
+	end()
+	sw.Lap()
+	sw.Stop()
+
+	var buf bytes.Buffer
+	if err := sw.ExportTraceJSON(&buf); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	var trace struct {
+		TraceEvents []traceEvent `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &trace); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	cats := make(map[string]int)
+	for _, e := range trace.TraceEvents {
+		cats[e.Cat]++
+	}
+
+	if cats["session"] != 1 || cats["lap"] != 2 || cats["section"] != 1 {
+		t.Errorf("ExportTraceJSON: unexpected events %v\n", cats)
+	}
+
+	buf.Reset()
+	if err := New().ExportTraceJSON(&buf); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	if bytes.Contains(buf.Bytes(), []byte(`"session"`)) {
+		t.Error("ExportTraceJSON: a reseted stopwatch should not export a session")
+	}
+}
+
+func TestStopwatch_SectionOffsetAfterPause(t *testing.T) {
+	c := newClock()
+	sw := Start(0, WithClock(c))
+
+	c.Advance(time.Second)
+	end := sw.Section("work")
+	c.Advance(time.Second)
+	sw.Stop()
+	c.Advance(10 * time.Second)
+	sw.Start(0)
+	c.Advance(time.Second)
+	end()
+	sw.Stop()
+
+	var buf bytes.Buffer
+	if err := sw.ExportTraceJSON(&buf); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	var trace struct {
+		TraceEvents []traceEvent `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &trace); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	for _, e := range trace.TraceEvents {
+		if e.Cat == "section" && e.Ts != traceMicros(time.Second) {
+			t.Errorf("ExportTraceJSON: expected the section at %v, got %v\n", traceMicros(time.Second), e.Ts)
+		}
+	}
+}
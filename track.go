@@ -0,0 +1,51 @@
+package stopwatch
+
+import (
+	"fmt"
+	"io"
+	"log"
+)
+
+// TrackOption configures Track.
+type TrackOption func(*tracker)
+
+type tracker struct {
+	printf func(format string, v ...interface{})
+	opts   []Option
+}
+
+// WithTrackWriter writes the elapsed time to w instead of logging it.
+func WithTrackWriter(w io.Writer) TrackOption {
+	return func(t *tracker) {
+		t.printf = func(format string, v ...interface{}) { fmt.Fprintf(w, format, v...) }
+	}
+}
+
+// WithTrackLogger logs the elapsed time to l instead of the standard logger.
+func WithTrackLogger(l *log.Logger) TrackOption {
+	return func(t *tracker) { t.printf = l.Printf }
+}
+
+// WithTrackStopwatchOptions sets the options of the stopwatch started by
+// Track.
+func WithTrackStopwatchOptions(opts ...Option) TrackOption {
+	return func(t *tracker) { t.opts = opts }
+}
+
+// Track starts a new stopwatch and returns a function that logs the given
+// message with the elapsed time attached. Unlike defer Start(0).Log(msg) it
+// reads well and is configurable, the elapsed time is logged with
+// log.Printf unless another destination is set.
+// Example : defer stopwatch.Track("sync users")()
+// Output: 2014/02/10 00:44:56 sync users - elapsed: 2.000169591s
+func Track(msg string, opts ...TrackOption) func() {
+	t := &tracker{printf: log.Printf}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	s := Start(0, t.opts...)
+	return func() {
+		t.printf("%s - elapsed: %s\n", msg, s.ElapsedTime())
+	}
+}
@@ -0,0 +1,34 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bytes"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestTrack(t *testing.T) {
+	c := newClock()
+	var buf bytes.Buffer
+
+	done := Track("sync users", WithTrackWriter(&buf), WithTrackStopwatchOptions(WithClock(c)))
+	c.Advance(2 * time.Second)
+	done()
+
+	if got, expected := buf.String(), "sync users - elapsed: 2s\n"; got != expected {
+		t.Errorf("Track: got: %q expected: %q\n", got, expected)
+	}
+
+	buf.Reset()
+	l := log.New(&buf, "app: ", 0)
+	done = Track("load", WithTrackLogger(l), WithTrackStopwatchOptions(WithClock(c)))
+	c.Advance(time.Second)
+	done()
+
+	if got, expected := buf.String(), "app: load - elapsed: 1s\n"; got != expected {
+		t.Errorf("Track: got: %q expected: %q\n", got, expected)
+	}
+}
@@ -0,0 +1,91 @@
+package stopwatch
+
+import (
+	"io"
+	"sync"
+)
+
+// Reader is an io.Reader that times the transfer of the bytes read from it.
+// The stopwatch starts with the first Read and stops at the end of the
+// input or on Close. Every Read is recorded with Tick, so Bytes and Rate are
+// the bytes read and the bytes per second. Laps can be taken at any time.
+type Reader struct {
+	*Stopwatch
+	r     io.Reader
+	start sync.Once
+}
+
+// NewReader returns a Reader reading from r.
+// Example : r := stopwatch.NewReader(resp.Body)
+func NewReader(r io.Reader, opts ...Option) *Reader {
+	return &Reader{Stopwatch: New(opts...), r: r}
+}
+
+// Read reads from the underlying reader.
+func (r *Reader) Read(p []byte) (int, error) {
+	r.start.Do(func() { r.Start(0) })
+
+	n, err := r.r.Read(p)
+	r.Tick(n)
+	if err == io.EOF {
+		r.Stop()
+	}
+	return n, err
+}
+
+// Close stops the stopwatch and closes the underlying reader if it is an
+// io.Closer.
+func (r *Reader) Close() error {
+	r.Stop()
+	if c, ok := r.r.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Bytes returns the number of bytes read.
+func (r *Reader) Bytes() int64 { return r.Ticks() }
+
+// MBps returns the throughput in megabytes (10^6 bytes) per second.
+func (r *Reader) MBps() float64 { return r.Rate() / 1e6 }
+
+// Writer is an io.Writer that times the transfer of the bytes written to
+// it. The stopwatch starts with the first Write and stops on Close. Every
+// Write is recorded with Tick, so Bytes and Rate are the bytes written and
+// the bytes per second. Laps can be taken at any time.
+type Writer struct {
+	*Stopwatch
+	w     io.Writer
+	start sync.Once
+}
+
+// NewWriter returns a Writer writing to w.
+// Example : w := stopwatch.NewWriter(f)
+func NewWriter(w io.Writer, opts ...Option) *Writer {
+	return &Writer{Stopwatch: New(opts...), w: w}
+}
+
+// Write writes to the underlying writer.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.start.Do(func() { w.Start(0) })
+
+	n, err := w.w.Write(p)
+	w.Tick(n)
+	return n, err
+}
+
+// Close stops the stopwatch and closes the underlying writer if it is an
+// io.Closer.
+func (w *Writer) Close() error {
+	w.Stop()
+	if c, ok := w.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Bytes returns the number of bytes written.
+func (w *Writer) Bytes() int64 { return w.Ticks() }
+
+// MBps returns the throughput in megabytes (10^6 bytes) per second.
+func (w *Writer) MBps() float64 { return w.Rate() / 1e6 }
@@ -0,0 +1,75 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/stopwatch/clocktest"
+)
+
+// slowReader advances the clock by a second for each read.
+type slowReader struct {
+	r io.Reader
+	c *clocktest.Clock
+}
+
+func (s slowReader) Read(p []byte) (int, error) {
+	s.c.Advance(time.Second)
+	return s.r.Read(p)
+}
+
+func TestReader(t *testing.T) {
+	c := newClock()
+	src := slowReader{r: strings.NewReader(strings.Repeat("x", 4000000)), c: c}
+	r := NewReader(src, WithClock(c))
+	if !r.IsReseted() {
+		t.Error("NewReader: the stopwatch should start with the first Read")
+	}
+
+	buf := make([]byte, 1000000)
+	for {
+		if _, err := r.Read(buf); err == io.EOF {
+			break
+		}
+	}
+
+	if !r.IsStopped() {
+		t.Error("Reader: the stopwatch should stop at the end of the input")
+	}
+	if r.Bytes() != 4000000 {
+		t.Errorf("Reader: got: %d bytes expected: 4000000\n", r.Bytes())
+	}
+	// five reads, the last one hits the end of the input
+	if r.MBps() != 0.8 {
+		t.Errorf("Reader: got: %g MB/s expected: 0.8\n", r.MBps())
+	}
+}
+
+func TestWriter(t *testing.T) {
+	c := newClock()
+	var dst bytes.Buffer
+	w := NewWriter(&dst, WithClock(c))
+
+	w.Write([]byte("hello "))
+	c.Advance(time.Second)
+	w.Lap()
+	w.Write([]byte("world"))
+	c.Advance(time.Second)
+	w.Close()
+
+	if dst.String() != "hello world" {
+		t.Errorf("Writer: got: %q expected: hello world\n", dst.String())
+	}
+	if w.Bytes() != 11 || w.Rate() != 5.5 {
+		t.Errorf("Writer: got: %d bytes at %g/s expected: 11 bytes at 5.5/s\n", w.Bytes(), w.Rate())
+	}
+	if !w.IsStopped() || len(w.Laps()) != 1 {
+		t.Error("Writer: the stopwatch should be stopped with a lap")
+	}
+}
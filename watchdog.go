@@ -0,0 +1,84 @@
+package stopwatch
+
+import (
+	"log"
+	"time"
+)
+
+// watchdog trips once per session if the stopwatch runs for too long.
+type watchdog struct {
+	max     time.Duration
+	onTrip  func(*Stopwatch)
+	timer   *time.Timer
+	gen     int // incremented on each arm and disarm, stale checks are ignored
+	tripped bool
+}
+
+// WithWatchdog calls onTrip once per session if the stopwatch is still
+// running after an elapsed time of max, such as for a hung operation. A nil
+// onTrip logs the stopwatch with log.Printf instead. onTrip is called from
+// its own goroutine and may call the methods of the stopwatch, after the
+// hooks got an EventWatchdog.
+// Example : stopwatch.Start(0, stopwatch.WithWatchdog(time.Minute, nil))
+func WithWatchdog(max time.Duration, onTrip func(*Stopwatch)) Option {
+	return func(s *Stopwatch) {
+		s.watchdog = &watchdog{max: max, onTrip: onTrip}
+	}
+}
+
+// armWatchdog schedules the watchdog check at the time the elapsed time
+// reaches the maximum. The lock must be held.
+func (s *Stopwatch) armWatchdog() {
+	w := s.watchdog
+	if w == nil || w.tripped {
+		return
+	}
+
+	s.disarmWatchdog()
+
+	gen, sgen := w.gen, s.gen
+	w.timer = time.AfterFunc(w.max-s.elapsed(), func() { s.checkWatchdog(gen, sgen) })
+}
+
+// disarmWatchdog cancels the scheduled watchdog check. The lock must be held.
+func (s *Stopwatch) disarmWatchdog() {
+	w := s.watchdog
+	if w == nil {
+		return
+	}
+
+	w.gen++
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+}
+
+// checkWatchdog trips the watchdog if the stopwatch is still running past
+// the maximum, otherwise it checks again later. It does nothing if the
+// watchdog was disarmed after the check of generation gen was scheduled, or
+// if the stopwatch was put into a pool since, see Stopwatch.gen.
+func (s *Stopwatch) checkWatchdog(gen int, sgen uint64) {
+	s.mu.Lock()
+	w := s.watchdog
+	if s.gen != sgen || w.gen != gen || !s.isRunning() {
+		s.mu.Unlock()
+		return
+	}
+
+	elapsed := s.elapsed()
+	if elapsed < w.max {
+		s.armWatchdog()
+		s.mu.Unlock()
+		return
+	}
+
+	w.timer, w.tripped = nil, true
+	s.unlock(s.breach(EventWatchdog, s.section))
+
+	if w.onTrip == nil {
+		log.Printf("stopwatch: still running after %s (max %s): %s", elapsed, w.max, s)
+		return
+	}
+	w.onTrip(s)
+}
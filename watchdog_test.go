@@ -0,0 +1,44 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStopwatch_Watchdog(t *testing.T) {
+	c := newClock()
+	tripped := make(chan *Stopwatch, 2)
+	sw := Start(0, WithClock(c), WithWatchdog(10*time.Millisecond, func(s *Stopwatch) {
+		tripped <- s
+	}))
+	c.Advance(20 * time.Millisecond)
+
+	select {
+	case s := <-tripped:
+		if s != sw {
+			t.Error("WithWatchdog: got a different stopwatch")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WithWatchdog: the watchdog didn't trip")
+	}
+
+	// a stopped stopwatch doesn't trip
+	stopped := Start(0, WithClock(c), WithWatchdog(10*time.Millisecond, func(s *Stopwatch) {
+		tripped <- s
+	}))
+	stopped.Stop()
+	c.Advance(20 * time.Millisecond)
+
+	select {
+	case s := <-tripped:
+		if s == sw {
+			t.Error("WithWatchdog: the watchdog tripped twice in a session")
+		} else {
+			t.Error("WithWatchdog: a stopped stopwatch tripped")
+		}
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,92 @@
+package stopwatch
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"time"
+)
+
+// Func is a function that can be instrumented with layers, see Wrap.
+type Func func(ctx context.Context) error
+
+// Layer decorates a Func with instrumentation, such as timing or logging.
+type Layer func(next Func) Func
+
+// Wrap returns fn decorated with the given layers. The first layer is the
+// outermost one, it runs first and returns last.
+// Example : fn = stopwatch.Wrap(fn, stopwatch.Timed(), stopwatch.Logged("sync"))
+func Wrap(fn Func, layers ...Layer) Func {
+	return Chain(layers...)(fn)
+}
+
+// Chain composes the given layers into a single layer. The first layer is
+// the outermost one.
+func Chain(layers ...Layer) Layer {
+	return func(next Func) Func {
+		for i := len(layers) - 1; i >= 0; i-- {
+			next = layers[i](next)
+		}
+		return next
+	}
+}
+
+// Timed starts a new stopwatch for every call and stores it in the context
+// passed to the inner layers and the function, so they can take laps or open
+// sections. The stopwatch is stopped once the call returns.
+func Timed(opts ...Option) Layer {
+	return func(next Func) Func {
+		return func(ctx context.Context) error {
+			s := Start(0, opts...)
+			defer s.Stop()
+			return next(NewContext(ctx, s))
+		}
+	}
+}
+
+// Logged logs the duration of every call with log.Printf.
+// Output: 2014/02/10 00:44:56 sync - elapsed: 2.000169591s
+func Logged(name string) Layer {
+	return Observed(func(elapsed time.Duration, err error) {
+		if err != nil {
+			log.Printf("%s - elapsed: %s error: %s\n", name, elapsed, err)
+			return
+		}
+		log.Printf("%s - elapsed: %s\n", name, elapsed)
+	})
+}
+
+// Observed calls fn with the duration and the error of every call, such as
+// to record them in a metric.
+//
+// The duration is measured with the stopwatch in the context, if there is
+// one, otherwise with a new stopwatch.
+func Observed(fn func(elapsed time.Duration, err error)) Layer {
+	return func(next Func) Func {
+		return func(ctx context.Context) error {
+			s := FromContext(ctx)
+			if s == nil {
+				s = Start(0)
+			}
+
+			before := s.ElapsedTime()
+			err := next(ctx)
+			fn(s.ElapsedTime()-before, err)
+			return err
+		}
+	}
+}
+
+// Sampled applies the given layers to a random fraction of the calls, such
+// as 0.01 for 1% of them. The remaining calls run without them.
+func Sampled(rate float64, layers ...Layer) Layer {
+	return func(next Func) Func {
+		sampled := Chain(layers...)(next)
+		return func(ctx context.Context) error {
+			if rate >= 1 || rand.Float64() < rate {
+				return sampled(ctx)
+			}
+			return next(ctx)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package stopwatch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWrap(t *testing.T) {
+	c := newClock()
+	var order []string
+	layer := func(name string) Layer {
+		return func(next Func) Func {
+			return func(ctx context.Context) error {
+				order = append(order, name)
+				return next(ctx)
+			}
+		}
+	}
+
+	errFailed := errors.New("failed")
+	var elapsed time.Duration
+	var observed error
+	fn := Wrap(func(ctx context.Context) error {
+		s := FromContext(ctx)
+		if s == nil {
+			t.Fatal("Wrap: Timed should store a stopwatch in the context")
+		}
+		c.Advance(time.Second)
+		return errFailed
+	},
+		layer("outer"),
+		Timed(WithClock(c)),
+		layer("inner"),
+		Observed(func(d time.Duration, err error) { elapsed, observed = d, err }),
+	)
+
+	if err := fn(context.Background()); err != errFailed {
+		t.Errorf("Wrap: got: %v expected: %v\n", err, errFailed)
+	}
+
+	if len(order) != 2 || order[0] != "outer" || order[1] != "inner" {
+		t.Errorf("Wrap: got: %v expected: [outer inner]\n", order)
+	}
+
+	if elapsed != time.Second || observed != errFailed {
+		t.Errorf("Observed: got: %s %v expected: %s %v\n", elapsed, observed, time.Second, errFailed)
+	}
+}
+
+func TestSampled(t *testing.T) {
+	var calls, observed int
+	fn := func(ctx context.Context) error {
+		calls++
+		return nil
+	}
+	observe := Observed(func(time.Duration, error) { observed++ })
+
+	never := Wrap(fn, Sampled(0, observe))
+	always := Wrap(fn, Sampled(1, observe))
+	for i := 0; i < 10; i++ {
+		never(context.Background())
+		always(context.Background())
+	}
+
+	if calls != 20 || observed != 10 {
+		t.Errorf("Sampled: got: %d calls %d observed expected: 20 calls 10 observed\n", calls, observed)
+	}
+}
@@ -0,0 +1,16 @@
+module github.com/fatih/stopwatch/zapstopwatch
+
+go 1.25.0
+
+require (
+	github.com/fatih/stopwatch v0.0.0-00010101000000-000000000000
+	go.uber.org/zap v1.28.0
+)
+
+require (
+	github.com/stretchr/testify v1.12.1 // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+	go.yaml.in/yaml/v3 v3.0.5 // indirect
+)
+
+replace github.com/fatih/stopwatch => ../
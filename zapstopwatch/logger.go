@@ -0,0 +1,31 @@
+// Package zapstopwatch logs stopwatches with zap, see stopwatch.Logger.
+package zapstopwatch
+
+import (
+	"sort"
+	"time"
+
+	"github.com/fatih/stopwatch"
+	"go.uber.org/zap"
+)
+
+// Logger returns a stopwatch.Logger that logs with l at the info level. The
+// elapsed time is the "elapsed" field, the tags of the stopwatch are string
+// fields.
+// Example : stopwatch.SetLogger(zapstopwatch.Logger(logger))
+func Logger(l *zap.Logger) stopwatch.Logger {
+	return stopwatch.LoggerFunc(func(msg string, elapsed time.Duration, tags map[string]string) {
+		keys := make([]string, 0, len(tags))
+		for k := range tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		fields := make([]zap.Field, 0, len(tags)+1)
+		fields = append(fields, zap.Duration("elapsed", elapsed))
+		for _, k := range keys {
+			fields = append(fields, zap.String(k, tags[k]))
+		}
+		l.Info(msg, fields...)
+	})
+}
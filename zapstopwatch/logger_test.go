@@ -0,0 +1,32 @@
+package zapstopwatch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fatih/stopwatch"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	sw := stopwatch.Start(0, stopwatch.WithLogger(Logger(zap.New(core))))
+	sw.SetTag("job", "a")
+	sw.Stop()
+	sw.Log("sync")
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("Logger: got: %d entries expected: 1\n", len(entries))
+	}
+
+	fields := entries[0].ContextMap()
+	if entries[0].Message != "sync" || fields["job"] != "a" {
+		t.Errorf("Logger: got: %s %v expected: sync with job=a\n", entries[0].Message, fields)
+	}
+	if d, ok := fields["elapsed"].(time.Duration); !ok || d != sw.ElapsedTime() {
+		t.Errorf("Logger: got elapsed: %v expected: %s\n", fields["elapsed"], sw.ElapsedTime())
+	}
+}
@@ -0,0 +1,16 @@
+module github.com/fatih/stopwatch/zerologstopwatch
+
+go 1.25.0
+
+require (
+	github.com/fatih/stopwatch v0.0.0-00010101000000-000000000000
+	github.com/rs/zerolog v1.35.1
+)
+
+require (
+	github.com/mattn/go-colorable v0.1.14 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+)
+
+replace github.com/fatih/stopwatch => ../
@@ -0,0 +1,32 @@
+// Package zerologstopwatch logs stopwatches with zerolog, see
+// stopwatch.Logger.
+package zerologstopwatch
+
+import (
+	"sort"
+	"time"
+
+	"github.com/fatih/stopwatch"
+	"github.com/rs/zerolog"
+)
+
+// Logger returns a stopwatch.Logger that logs with l at the info level. The
+// elapsed time is the "elapsed" field, in the unit of
+// zerolog.DurationFieldUnit, the tags of the stopwatch are string fields,
+// sorted by key.
+// Example : stopwatch.SetLogger(zerologstopwatch.Logger(log.Logger))
+func Logger(l zerolog.Logger) stopwatch.Logger {
+	return stopwatch.LoggerFunc(func(msg string, elapsed time.Duration, tags map[string]string) {
+		keys := make([]string, 0, len(tags))
+		for k := range tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		e := l.Info().Dur("elapsed", elapsed)
+		for _, k := range keys {
+			e = e.Str(k, tags[k])
+		}
+		e.Msg(msg)
+	})
+}
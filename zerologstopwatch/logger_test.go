@@ -0,0 +1,38 @@
+package zerologstopwatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/fatih/stopwatch"
+	"github.com/rs/zerolog"
+)
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	sw := stopwatch.Start(0, stopwatch.WithLogger(Logger(zerolog.New(&buf))))
+	sw.SetTag("job", "a")
+	sw.SetTag("env", "prod")
+	sw.Stop()
+	sw.Log("sync")
+
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("error: %s\n", err)
+	}
+
+	if line["message"] != "sync" || line["job"] != "a" || line["level"] != "info" {
+		t.Errorf("Logger: got: %s expected: sync with job=a\n", buf.String())
+	}
+
+	if i, j := bytes.Index(buf.Bytes(), []byte(`"env"`)), bytes.Index(buf.Bytes(), []byte(`"job"`)); i > j {
+		t.Errorf("Logger: got: %s expected the tags sorted by key\n", buf.String())
+	}
+
+	ms := float64(sw.ElapsedTime()) / float64(time.Millisecond)
+	if line["elapsed"] != ms {
+		t.Errorf("Logger: got elapsed: %v expected: %v\n", line["elapsed"], ms)
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/fatih/stopwatch/zstdstopwatch
+
+go 1.25.0
+
+require (
+	github.com/fatih/stopwatch v0.0.0-00010101000000-000000000000
+	github.com/klauspost/compress v1.19.1
+)
+
+replace github.com/fatih/stopwatch => ../
@@ -0,0 +1,34 @@
+// Package zstdstopwatch registers zstd compression for files with the ".zst"
+// extension, see stopwatch.RegisterCompressor. It is enabled by importing it:
+//
+//	import _ "github.com/fatih/stopwatch/zstdstopwatch"
+package zstdstopwatch
+
+import (
+	"io"
+
+	"github.com/fatih/stopwatch"
+	"github.com/klauspost/compress/zstd"
+)
+
+func init() {
+	stopwatch.RegisterCompressor(".zst", Compressor())
+}
+
+// Compressor returns a stopwatch.Compressor for zstd. It is registered for
+// ".zst" when the package is imported.
+func Compressor() stopwatch.Compressor {
+	return stopwatch.Compressor{
+		NewWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return zstd.NewWriter(w)
+		},
+		// the decoder continues with the next frame, as required for archives
+		NewReader: func(r io.Reader) (io.ReadCloser, error) {
+			d, err := zstd.NewReader(r)
+			if err != nil {
+				return nil, err
+			}
+			return d.IOReadCloser(), nil
+		},
+	}
+}
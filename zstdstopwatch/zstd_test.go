@@ -0,0 +1,50 @@
+//go:build !stopwatch_off
+// +build !stopwatch_off
+
+package zstdstopwatch
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fatih/stopwatch"
+)
+
+func TestCompressor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.jsonl.zst")
+	a := stopwatch.NewArchive(path, stopwatch.Retention{}, nil)
+
+	// every Add appends a new zstd frame
+	for i := 0; i < 2; i++ {
+		sw := stopwatch.Start(0)
+		time.Sleep(time.Millisecond)
+		sw.Lap()
+		sw.Stop()
+
+		if err := a.Add(sw); err != nil {
+			t.Fatalf("Add: error: %s\n", err)
+		}
+	}
+
+	sessions, err := a.Sessions()
+	if err != nil {
+		t.Fatalf("Sessions: error: %s\n", err)
+	}
+
+	if len(sessions) != 2 {
+		t.Fatalf("Sessions: expected 2 sessions, got %d\n", len(sessions))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: error: %s\n", err)
+	}
+
+	magic := []byte{0x28, 0xb5, 0x2f, 0xfd}
+	if !bytes.HasPrefix(data, magic) {
+		t.Errorf("Sessions: the archive is not zstd compressed\n")
+	}
+}